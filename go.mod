@@ -10,7 +10,10 @@ require (
 	github.com/mholt/archiver v2.1.0+incompatible
 	github.com/spf13/viper v1.2.1
 	golang.org/x/crypto v0.0.0-20181001203147-e3636079e1a4
+	gopkg.in/src-d/go-billy.v4 v4.3.0
+	gopkg.in/src-d/go-git.v4 v4.7.0
 	gopkg.in/yaml.v2 v2.2.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 replace github.com/jfrog/jfrog-client-go => github.com/jfrog/jfrog-client-go v0.1.0
@@ -18,15 +18,25 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	goutils "github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/golang"
 	npmutils "github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/sbom"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildaddgit"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildaddissues"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildclean"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildcollectci"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildcollectenv"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/builddistribute"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildexport"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildimport"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildlist"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildpublish"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildsbom"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/buildstoreconfig"
+	cachedocs "github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/cache"
 	configdocs "github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/config"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/copy"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/delete"
@@ -49,16 +59,22 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/ping"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/setprops"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/specvalidate"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/syncdownload"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/syncupload"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/artifactory/use"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/ioutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	rtclientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -106,11 +122,35 @@ func GetCommands() []cli.Command {
 			Usage:     download.Description,
 			HelpName:  common.CreateUsage("rt download", download.Description, download.Usage),
 			UsageText: download.Arguments,
-			ArgsUsage: common.CreateEnvVars(),
+			ArgsUsage: common.CreateEnvVars(download.EnvVar),
 			Action: func(c *cli.Context) {
 				downloadCmd(c)
 			},
 		},
+		{
+			Name:      "sync-upload",
+			Flags:     getSyncUploadFlags(),
+			Aliases:   []string{"su"},
+			Usage:     syncupload.Description,
+			HelpName:  common.CreateUsage("rt sync-upload", syncupload.Description, syncupload.Usage),
+			UsageText: syncupload.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				syncUploadCmd(c)
+			},
+		},
+		{
+			Name:      "sync-download",
+			Flags:     getSyncDownloadFlags(),
+			Aliases:   []string{"sd"},
+			Usage:     syncdownload.Description,
+			HelpName:  common.CreateUsage("rt sync-download", syncdownload.Description, syncdownload.Usage),
+			UsageText: syncdownload.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				syncDownloadCmd(c)
+			},
+		},
 		{
 			Name:      "move",
 			Flags:     getMoveFlags(),
@@ -159,6 +199,18 @@ func GetCommands() []cli.Command {
 				searchCmd(c)
 			},
 		},
+		{
+			Name:      "spec-validate",
+			Flags:     getSpecValidateFlags(),
+			Aliases:   []string{"sv"},
+			Usage:     specvalidate.Description,
+			HelpName:  common.CreateUsage("rt spec-validate", specvalidate.Description, specvalidate.Usage),
+			UsageText: specvalidate.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				specValidateCmd(c)
+			},
+		},
 		{
 			Name:      "set-props",
 			Flags:     getSetPropertiesFlags(),
@@ -195,6 +247,30 @@ func GetCommands() []cli.Command {
 				buildPublishCmd(c)
 			},
 		},
+		{
+			Name:      "build-export",
+			Flags:     getBuildExportFlags(),
+			Aliases:   []string{"be"},
+			Usage:     buildexport.Description,
+			HelpName:  common.CreateUsage("rt build-export", buildexport.Description, buildexport.Usage),
+			UsageText: buildexport.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildExportCmd(c)
+			},
+		},
+		{
+			Name:      "build-import",
+			Flags:     getBuildImportFlags(),
+			Aliases:   []string{"bi"},
+			Usage:     buildimport.Description,
+			HelpName:  common.CreateUsage("rt build-import", buildimport.Description, buildimport.Usage),
+			UsageText: buildimport.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildImportCmd(c)
+			},
+		},
 		{
 			Name:      "build-collect-env",
 			Flags:     []cli.Flag{},
@@ -207,6 +283,18 @@ func GetCommands() []cli.Command {
 				buildCollectEnvCmd(c)
 			},
 		},
+		{
+			Name:      "build-collect-ci",
+			Flags:     []cli.Flag{},
+			Aliases:   []string{"bci"},
+			Usage:     buildcollectci.Description,
+			HelpName:  common.CreateUsage("rt build-collect-ci", buildcollectci.Description, buildcollectci.Usage),
+			UsageText: buildcollectci.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildCollectCiCmd(c)
+			},
+		},
 		{
 			Name:      "build-add-dependencies",
 			Flags:     getBuildAddDependenciesFlags(),
@@ -221,7 +309,7 @@ func GetCommands() []cli.Command {
 		},
 		{
 			Name:      "build-add-git",
-			Flags:     []cli.Flag{},
+			Flags:     getBuildAddGitFlags(),
 			Aliases:   []string{"bag"},
 			Usage:     buildaddgit.Description,
 			HelpName:  common.CreateUsage("rt build-add-git", buildaddgit.Description, buildaddgit.Usage),
@@ -231,6 +319,18 @@ func GetCommands() []cli.Command {
 				buildAddGitCmd(c)
 			},
 		},
+		{
+			Name:      "build-add-issues",
+			Flags:     getBuildAddIssuesFlags(),
+			Aliases:   []string{"bai"},
+			Usage:     buildaddissues.Description,
+			HelpName:  common.CreateUsage("rt build-add-issues", buildaddissues.Description, buildaddissues.Usage),
+			UsageText: buildaddissues.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildAddIssuesCmd(c)
+			},
+		},
 		{
 			Name:      "build-scan",
 			Flags:     getServerFlags(),
@@ -291,6 +391,51 @@ func GetCommands() []cli.Command {
 				buildDiscardCmd(c)
 			},
 		},
+		{
+			Name:      "build-diff",
+			Flags:     getBuildDiffFlags(),
+			Aliases:   []string{"bdf"},
+			Usage:     builddiff.Description,
+			HelpName:  common.CreateUsage("rt build-diff", builddiff.Description, builddiff.Usage),
+			UsageText: builddiff.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildDiffCmd(c)
+			},
+		},
+		{
+			Name:      "build-sbom",
+			Flags:     getBuildSbomFlags(),
+			Aliases:   []string{"bsb"},
+			Usage:     buildsbom.Description,
+			HelpName:  common.CreateUsage("rt build-sbom", buildsbom.Description, buildsbom.Usage),
+			UsageText: buildsbom.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildSbomCmd(c)
+			},
+		},
+		{
+			Name:      "build-list",
+			Aliases:   []string{"bl"},
+			Usage:     buildlist.Description,
+			HelpName:  common.CreateUsage("rt build-list", buildlist.Description, buildlist.Usage),
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildListCmd(c)
+			},
+		},
+		{
+			Name:      "build-store-config",
+			Flags:     getBuildStoreConfigFlags(),
+			Aliases:   []string{"bsc"},
+			Usage:     buildstoreconfig.Description,
+			HelpName:  common.CreateUsage("rt build-store-config", buildstoreconfig.Description, buildstoreconfig.Usage),
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildStoreConfigCmd(c)
+			},
+		},
 		{
 			Name:      "git-lfs-clean",
 			Flags:     getGitLfsCleanFlags(),
@@ -303,6 +448,16 @@ func GetCommands() []cli.Command {
 				gitLfsCleanCmd(c)
 			},
 		},
+		{
+			Name:      "cache",
+			Usage:     cachedocs.Description,
+			HelpName:  common.CreateUsage("rt cache", cachedocs.Description, cachedocs.Usage),
+			UsageText: cachedocs.Arguments,
+			ArgsUsage: common.CreateEnvVars(cachedocs.EnvVar),
+			Action: func(c *cli.Context) {
+				cacheCmd(c)
+			},
+		},
 		{
 			Name:      "mvn",
 			Flags:     getBuildToolFlags(),
@@ -478,6 +633,10 @@ func getBaseFlags() []cli.Flag {
 		cli.StringFlag{
 			Name:  "apikey",
 			Usage: "[Optional] Artifactory API key.",
+		},
+		cli.StringFlag{
+			Name:  "access-token",
+			Usage: "[Optional] Artifactory access token. The docker, maven, gradle, go and nuget commands send it as the password of the user, so it requires the user option for these commands.",
 		})
 }
 
@@ -520,14 +679,23 @@ func getSortLimitFlags() []cli.Flag {
 
 func getUploadFlags() []cli.Flag {
 	uploadFlags := append(getServerFlags(), getSpecFlags()...)
+	uploadFlags = append(uploadFlags, getPlanFlags()...)
 	return append(uploadFlags, []cli.Flag{
 		cli.StringFlag{
 			Name:  "build-name",
-			Usage: "[Optional] Build name. Providing this option will record all uploaded artifacts for later build info publication.",
+			Usage: "[Optional] Build name. Providing this option will record all uploaded artifacts for later build info publication. If only the --build-number option is set, the build name of the .jfrog/project.yaml project configuration is used.",
 		},
 		cli.StringFlag{
 			Name:  "build-number",
-			Usage: "[Optional] Build number. Providing this option will record all uploaded artifacts for later build info publication.",
+			Usage: "[Optional] Build number. Providing this option will record all uploaded artifacts for later build info publication. If only the --build-number option is set, the build name of the .jfrog/project.yaml project configuration is used.",
+		},
+		cli.StringFlag{
+			Name:  "module",
+			Usage: "[Optional] Optional module name for the build-info. Build name and number options are mandatory when this option is provided.",
+		},
+		cli.StringFlag{
+			Name:  "artifact-type",
+			Usage: "[Optional] Type of the uploaded artifacts, to be recorded in the build-info. If not set, the type is inferred from the file extension.",
 		},
 		cli.StringFlag{
 			Name:  "props",
@@ -569,6 +737,10 @@ func getUploadFlags() []cli.Flag {
 			Name:  "include-dirs",
 			Usage: "[Default: false] Set to true if you'd like to also apply the source path pattern for directories and not just for files.",
 		},
+		cli.BoolFlag{
+			Name:  "resume",
+			Usage: "[Default: false] Set to true to resume a previous upload of the same spec, which did not complete. Artifacts already uploaded are verified by checksum and not transferred again.",
+		},
 		getFailNoOpFlag(),
 		getExcludePatternsFlag(),
 		getThreadsFlag(),
@@ -578,14 +750,19 @@ func getUploadFlags() []cli.Flag {
 func getDownloadFlags() []cli.Flag {
 	downloadFlags := append(getServerFlags(), getSortLimitFlags()...)
 	downloadFlags = append(downloadFlags, getSpecFlags()...)
+	downloadFlags = append(downloadFlags, getPlanFlags()...)
 	return append(downloadFlags, []cli.Flag{
 		cli.StringFlag{
 			Name:  "build-name",
-			Usage: "[Optional] Build name. Providing this option will record all downloaded artifacts for later build info publication.",
+			Usage: "[Optional] Build name. Providing this option will record all downloaded artifacts for later build info publication. If only the --build-number option is set, the build name of the .jfrog/project.yaml project configuration is used.",
 		},
 		cli.StringFlag{
 			Name:  "build-number",
-			Usage: "[Optional] Build number. Providing this option will record all downloaded artifacts for later build info publication.",
+			Usage: "[Optional] Build number. Providing this option will record all downloaded artifacts for later build info publication. If only the --build-number option is set, the build name of the .jfrog/project.yaml project configuration is used.",
+		},
+		cli.StringFlag{
+			Name:  "module",
+			Usage: "[Optional] Optional module name for the build-info. Build name and number options are mandatory when this option is provided.",
 		},
 		cli.StringFlag{
 			Name:  "props",
@@ -629,6 +806,10 @@ func getDownloadFlags() []cli.Flag {
 			Name:  "validate-symlinks",
 			Usage: "[Default: false] Set to true to perform a checksum validation when downloading symbolic links.",
 		},
+		cli.BoolFlag{
+			Name:  "cache",
+			Usage: "[Default: false] Set to true to use the local download cache. Files which exist in the cache with the checksum reported by Artifactory are taken from the cache instead of being downloaded.",
+		},
 		cli.BoolFlag{
 			Name:  "include-dirs",
 			Usage: "[Default: false] Set to true if you'd like to also apply the target path pattern for folders and not just for files in Artifactory.",
@@ -640,11 +821,46 @@ func getDownloadFlags() []cli.Flag {
 	}...)
 }
 
+func getSyncFlags() []cli.Flag {
+	syncFlags := append(getServerFlags(), getSpecFlags()...)
+	return append(syncFlags, []cli.Flag{
+		cli.BoolTFlag{
+			Name:  "recursive",
+			Usage: "[Default: true] Set to false if you do not wish to sync files in sub-folders.",
+		},
+		cli.BoolFlag{
+			Name:  "delete",
+			Usage: "[Default: false] Set to true to delete files on the target side, which do not exist on the source side.",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "[Default: false] Set to true to print the sync plan without transferring or deleting any files.",
+		},
+		cli.StringFlag{
+			Name:  "retries",
+			Usage: "[Default: " + strconv.Itoa(cliutils.Retries) + "] Number of transfer retries.",
+		},
+		getFailNoOpFlag(),
+		getThreadsFlag(),
+	}...)
+}
+
+func getSyncUploadFlags() []cli.Flag {
+	return append(getSyncFlags(), cli.StringFlag{
+		Name:  "props",
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\" to be attached to the uploaded artifacts.",
+	})
+}
+
+func getSyncDownloadFlags() []cli.Flag {
+	return getSyncFlags()
+}
+
 func getBuildToolFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
 			Name:  "build-name",
-			Usage: "[Optional] Providing this option will collect and record build info for this build name.",
+			Usage: "[Optional] Providing this option will collect and record build info for this build name. If only the --build-number option is set, the build name of the .jfrog/project.yaml project configuration is used.",
 		},
 		cli.StringFlag{
 			Name:  "build-number",
@@ -656,7 +872,7 @@ func getBuildToolFlags() []cli.Flag {
 func getServerIdFlag() cli.Flag {
 	return cli.StringFlag{
 		Name:  "server-id",
-		Usage: "[Optional] Artifactory server ID configured using the config command.",
+		Usage: "[Optional] Artifactory server ID configured using the config command. If not set, the server ID of the .jfrog/project.yaml project configuration, or the default server, is used.",
 	}
 }
 
@@ -678,11 +894,41 @@ func getSpecFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
 			Name:  "spec",
-			Usage: "[Optional] Path to a File Spec.",
+			Usage: "[Optional] Path to a File Spec, in JSON or YAML format.",
 		},
 		cli.StringFlag{
 			Name:  "spec-vars",
-			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}.",
+			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}, or ${key1:-default} to give a default value. Variables which aren't set are replaced by the environment variables with the same name.",
+		},
+	}
+}
+
+func getPlanFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "plan-out",
+			Usage: "[Optional] Path to a file, to which the plan of the command is written, instead of executing it. The plan lists the files the command would handle, with their targets, sizes and checksums.",
+		},
+		cli.StringFlag{
+			Name:  "plan-in",
+			Usage: "[Optional] Path to a plan written by the plan-out option, to be executed exactly. The plan replaces the arguments and the spec of the command, and isn't executed if any of its files were changed since it was created.",
+		},
+	}
+}
+
+func getSpecValidateFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "command",
+			Usage: "[Optional] The command the File Spec is used with, which is one of: " + strings.Join(spec.GetSpecCommands(), ", ") + ". If set, the spec is also checked for the fields the command requires and supports.",
+		},
+		cli.StringFlag{
+			Name:  "spec-vars",
+			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec.",
+		},
+		cli.BoolFlag{
+			Name:  "schema",
+			Usage: "[Default: false] Set to true to print the JSON Schema of the File Specs, instead of validating a File Spec.",
 		},
 	}
 }
@@ -764,6 +1010,7 @@ func getGoPublishFlags() []cli.Flag {
 func getMoveFlags() []cli.Flag {
 	moveFlags := append(getServerFlags(), getSortLimitFlags()...)
 	moveFlags = append(moveFlags, getSpecFlags()...)
+	moveFlags = append(moveFlags, getPlanFlags()...)
 	return append(moveFlags, []cli.Flag{
 		cli.BoolTFlag{
 			Name:  "recursive",
@@ -795,6 +1042,7 @@ func getMoveFlags() []cli.Flag {
 func getCopyFlags() []cli.Flag {
 	copyFlags := append(getServerFlags(), getSortLimitFlags()...)
 	copyFlags = append(copyFlags, getSpecFlags()...)
+	copyFlags = append(copyFlags, getPlanFlags()...)
 	return append(copyFlags, []cli.Flag{
 		cli.BoolTFlag{
 			Name:  "recursive",
@@ -825,6 +1073,7 @@ func getCopyFlags() []cli.Flag {
 func getDeleteFlags() []cli.Flag {
 	deleteFlags := append(getServerFlags(), getSortLimitFlags()...)
 	deleteFlags = append(deleteFlags, getSpecFlags()...)
+	deleteFlags = append(deleteFlags, getPlanFlags()...)
 	return append(deleteFlags, []cli.Flag{
 		cli.StringFlag{
 			Name:  "props",
@@ -896,6 +1145,7 @@ func getDeletePropertiesFlags() []cli.Flag {
 
 func getPropertiesFlags() []cli.Flag {
 	propsFlags := append(getServerFlags(), getSortLimitFlags()...)
+	propsFlags = append(propsFlags, getPlanFlags()...)
 	return append(propsFlags, []cli.Flag{
 		cli.BoolTFlag{
 			Name:  "recursive",
@@ -947,13 +1197,56 @@ func getBuildPublishFlags() []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:  "env-exclude",
-			Usage: "[Default: *password*;*secret*;*key*;*token*] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded.",
+			Usage: "[Default: " + buildinfo.DefaultEnvExclude + "] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded.",
+		},
+	}...)
+}
+
+func getBuildExportFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "build-url",
+			Usage: "[Optional] Can be used for setting the CI server build URL in the build-info.",
+		},
+		cli.StringFlag{
+			Name:  "env-include",
+			Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.",
+		},
+		cli.StringFlag{
+			Name:  "env-exclude",
+			Usage: "[Default: " + buildinfo.DefaultEnvExclude + "] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded.",
+		},
+		cli.StringFlag{
+			Name:  "user",
+			Usage: "[Optional] Artifactory username to be set as the build-info principal.",
+		},
+	}
+}
+
+func getBuildImportFlags() []cli.Flag {
+	return append(getServerFlags(), []cli.Flag{
+		cli.StringFlag{
+			Name:  "build-name",
+			Usage: "[Optional] If set, the build name in the file must match this value.",
+		},
+		cli.StringFlag{
+			Name:  "build-number",
+			Usage: "[Optional] If set, the build number in the file must match this value.",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "[Default: false] Set to true to only validate the build-info file, without publishing it.",
 		},
 	}...)
 }
 
 func getBuildAddDependenciesFlags() []cli.Flag {
-	return append(getSpecFlags(), []cli.Flag{
+	buildAddDependenciesFlags := append(getServerFlags(), getSpecFlags()...)
+	return append(buildAddDependenciesFlags, []cli.Flag{
+		cli.BoolFlag{
+			Name:  "from-rt",
+			Usage: "[Default: false] Set to true to search the files in Artifactory, rather than on the local file system. The files are added to the build info without being downloaded.",
+		},
 		cli.BoolTFlag{
 			Name:  "recursive",
 			Usage: "[Default: true] Set to false if you do not wish to collect artifacts in sub-folders to be added to the build info.",
@@ -966,10 +1259,32 @@ func getBuildAddDependenciesFlags() []cli.Flag {
 			Name:  "dry-run",
 			Usage: "[Default: false] Set to true to only get a summery of the dependencies that will be added to the build info.",
 		},
+		cli.StringFlag{
+			Name:  "module",
+			Usage: "[Optional] Optional module name for the build-info. If not set, the dependencies are added to the module named after the build.",
+		},
+		cli.StringFlag{
+			Name:  "scopes",
+			Usage: "[Optional] Comma-separated list of scopes of the dependencies, such as \"compile,runtime\".",
+		},
 		getExcludePatternsFlag(),
 	}...)
 }
 
+func getBuildAddGitFlags() []cli.Flag {
+	return append(getServerFlags(), cli.BoolFlag{
+		Name:  "changed-files",
+		Usage: "[Default: false] Set to true to collect the files changed since the git revision of the previous build published to Artifactory.",
+	})
+}
+
+func getBuildAddIssuesFlags() []cli.Flag {
+	return append(getServerFlags(), cli.StringFlag{
+		Name:  "config",
+		Usage: "[Mandatory] Path to a YAML file holding the issues tracker name and URL, the issue key regexps and the aggregation settings.",
+	})
+}
+
 func getBuildPromotionFlags() []cli.Flag {
 	return append(getServerFlags(), []cli.Flag{
 		cli.StringFlag{
@@ -1059,6 +1374,18 @@ func getConfigFlags() []cli.Flag {
 			Name:  "enc-password",
 			Usage: "[Default: true] If set to false then the configured password will not be encrypted using Artifatory's encryption API.",
 		},
+		cli.StringFlag{
+			Name:  "refresh-token",
+			Usage: "[Optional] Refresh token of the access token. If set, the access token is refreshed when it is about to expire.",
+		},
+		cli.StringFlag{
+			Name:  "passphrase",
+			Usage: "[Optional] Passphrase which encrypts the server token created by the export argument, and decrypts the token of the import argument. Can also be set by the JFROG_CLI_SERVER_TOKEN_PASSPHRASE environment variable. If not set and the command is interactive, the passphrase is prompted for.",
+		},
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "[Default: false] Set to true to allow the import argument to replace a configured server with the same server ID.",
+		},
 	}
 	return append(flags, getCommonFlags()...)
 }
@@ -1088,6 +1415,39 @@ func getBuildDiscardFlags() []cli.Flag {
 	}...)
 }
 
+func getBuildDiffFlags() []cli.Flag {
+	return append(getServerFlags(), cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] The output format of the differences. Accepts 'table' or 'json'.",
+	})
+}
+
+func getBuildSbomFlags() []cli.Flag {
+	return append(getServerFlags(), []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "[Mandatory] The SBOM format. Accepts 'spdx-json' or 'cyclonedx-json'.",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "[Optional] Path of the SBOM file to create. If not set, the SBOM is printed.",
+		},
+	}...)
+}
+
+func getBuildStoreConfigFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "dir",
+			Usage: "[Optional] Directory of the build info collected locally, which was not published yet.",
+		},
+		cli.StringFlag{
+			Name:  "expiry-days",
+			Usage: "[Optional] Number of days after which build info collected locally, which was not updated, is removed. Set to 0 to disable the expiry.",
+		},
+	}
+}
+
 func createArtifactoryDetailsByFlags(c *cli.Context, includeConfig bool) *config.ArtifactoryDetails {
 	artDetails := createArtifactoryDetails(c, includeConfig)
 	if artDetails.Url == "" {
@@ -1151,7 +1511,7 @@ func getRetries(c *cli.Context) (retries int) {
 }
 
 func validateServerId(serverId string) {
-	reservedIds := []string{"delete", "use", "show", "clear"}
+	reservedIds := []string{"delete", "use", "show", "clear", "export", "import"}
 	for _, reservedId := range reservedIds {
 		if serverId == reservedId {
 			cliutils.ExitOnErr(errors.New(fmt.Sprintf("Server can't have one of the following ID's: %s\n %s", strings.Join(reservedIds, ", "), cliutils.GetDocumentationMessage())))
@@ -1209,6 +1569,23 @@ func configCmd(c *cli.Context) {
 		} else if c.Args()[0] == "clear" {
 			commands.ClearConfig(configCommandConfiguration.Interactive)
 			return
+		} else if c.Args()[0] == "export" {
+			passphrase := getServerTokenPassphrase(c, configCommandConfiguration.Interactive, "Passphrase for encrypting the server token (leave empty to not encrypt it)")
+			serverToken, err := commands.Export(serverId, passphrase)
+			cliutils.ExitOnErr(err)
+			log.Output(serverToken)
+			return
+		} else if c.Args()[0] == "import" {
+			if len(c.Args()) != 2 {
+				cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+			}
+			encrypted, err := config.IsServerTokenEncrypted(c.Args()[1])
+			cliutils.ExitOnErr(err)
+			passphrase := getServerTokenPassphrase(c, configCommandConfiguration.Interactive && encrypted, "Passphrase of the server token")
+			details, err := commands.Import(c.Args()[1], passphrase, c.Bool("overwrite"))
+			cliutils.ExitOnErr(err)
+			log.Info(fmt.Sprintf("Imported server ID '%s' (%s).", details.ServerId, details.Url))
+			return
 		} else {
 			serverId = c.Args()[0]
 			validateServerId(serverId)
@@ -1220,7 +1597,8 @@ func configCmd(c *cli.Context) {
 }
 
 func mvnCmd(c *cli.Context) {
-	if c.NArg() != 2 {
+	// The config file path can be omitted if the project configuration sets the maven repositories.
+	if c.NArg() != 1 && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	configuration := createBuildToolConfiguration(c)
@@ -1229,7 +1607,8 @@ func mvnCmd(c *cli.Context) {
 }
 
 func gradleCmd(c *cli.Context) {
-	if c.NArg() != 2 {
+	// The config file path can be omitted if the project configuration sets the gradle repositories.
+	if c.NArg() != 1 && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	configuration := createBuildToolConfiguration(c)
@@ -1244,10 +1623,12 @@ func dockerPushCmd(c *cli.Context) {
 	artDetails := createArtifactoryDetailsByFlags(c, true)
 	imageTag := c.Args().Get(0)
 	targetRepo := c.Args().Get(1)
-	buildName := c.String("build-name")
+	buildName := getBuildName(c)
 	buildNumber := c.String("build-number")
 	validateBuildParams(buildName, buildNumber)
-	err := docker.PushDockerImage(imageTag, targetRepo, buildName, buildNumber, artDetails, getThreadsCount(c))
+	detailedSummary := cliutils.CreateDetailedSummary(c, "docker-push")
+	err := docker.PushDockerImage(imageTag, targetRepo, buildName, buildNumber, artDetails, getThreadsCount(c), detailedSummary)
+	printDetailedSummaryIfNeeded(detailedSummary, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1258,7 +1639,7 @@ func dockerPullCmd(c *cli.Context) {
 	artDetails := createArtifactoryDetailsByFlags(c, true)
 	imageTag := c.Args().Get(0)
 	sourceRepo := c.Args().Get(1)
-	buildName := c.String("build-name")
+	buildName := getBuildName(c)
 	buildNumber := c.String("build-number")
 	validateBuildParams(buildName, buildNumber)
 	err := docker.PullDockerImage(imageTag, sourceRepo, buildName, buildNumber, artDetails)
@@ -1266,14 +1647,15 @@ func dockerPullCmd(c *cli.Context) {
 }
 
 func nugetCmd(c *cli.Context) {
-	if c.NArg() != 2 {
+	// The repository can be omitted if it is set by the project configuration.
+	if c.NArg() != 1 && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	params := &nuget.Params{}
 	params.Args = c.Args().Get(0)
 	params.Flags = c.String("nuget-args")
-	params.RepoName = c.Args().Get(1)
-	params.BuildName = c.String("build-name")
+	params.RepoName = getRepository(c, c.Args().Get(1), config.Nuget, false)
+	params.BuildName = getBuildName(c)
 	params.BuildNumber = c.String("build-number")
 
 	path := c.String("solution-root")
@@ -1293,26 +1675,31 @@ func nugetDepsTreeCmd(c *cli.Context) {
 }
 
 func npmInstallCmd(c *cli.Context) {
-	if c.NArg() != 1 {
+	// The repository can be omitted if it is set by the project configuration.
+	if c.NArg() > 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	repository := getRepository(c, c.Args().Get(0), config.Npm, false)
 	configuration := createNpmConfiguration(c)
-	err := npm.Install(c.Args().Get(0), configuration)
+	err := npm.Install(repository, configuration)
 	cliutils.ExitOnErr(err)
 }
 
 func npmPublishCmd(c *cli.Context) {
-	if c.NArg() != 1 {
+	// The repository can be omitted if it is set by the project configuration.
+	if c.NArg() > 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	repository := getRepository(c, c.Args().Get(0), config.Npm, true)
 	configuration := createNpmConfiguration(c)
-	err := npm.Publish(c.Args().Get(0), configuration)
+	err := npm.Publish(repository, configuration)
 	cliutils.ExitOnErr(err)
 }
 
 func goPublishCmd(c *cli.Context) {
 	// When "self" set to true (default), there must be two arguments passed: target repo and the version
-	if c.BoolT("self") && c.NArg() != 2 {
+	// The target repo can be omitted if it is set by the project configuration.
+	if c.BoolT("self") && c.NArg() != 1 && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	// When "self" set to false, the target repository is mandatory but the version is not.
@@ -1324,10 +1711,16 @@ func goPublishCmd(c *cli.Context) {
 
 	logGoVersion()
 
-	buildName := c.String("build-name")
+	buildName := getBuildName(c)
 	buildNumber := c.String("build-number")
 	targetRepo := c.Args().Get(0)
 	version := c.Args().Get(1)
+	if c.BoolT("self") && c.NArg() == 1 {
+		// Only the version is passed.
+		targetRepo = ""
+		version = c.Args().Get(0)
+	}
+	targetRepo = getRepository(c, targetRepo, config.Go, true)
 	details := createArtifactoryDetailsByFlags(c, true)
 
 	succeeded, failed, err := golang.Publish(c.BoolT("self"), c.String("deps"), targetRepo, version, buildName, buildNumber, details)
@@ -1337,7 +1730,8 @@ func goPublishCmd(c *cli.Context) {
 
 func goCmd(c *cli.Context) {
 	// When the no-registry set to false (default), two arguments are mandatory: go command and the target repository
-	if !c.Bool("no-registry") && c.NArg() != 2 {
+	// The target repository can be omitted if it is set by the project configuration.
+	if !c.Bool("no-registry") && c.NArg() != 1 && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	// When the no-registry is set to true this means that the resolution will not be done via Artifactory.
@@ -1346,10 +1740,13 @@ func goCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
-	buildName := c.String("build-name")
+	buildName := getBuildName(c)
 	buildNumber := c.String("build-number")
 	goArg := c.Args().Get(0)
 	targetRepo := c.Args().Get(1)
+	if !c.Bool("no-registry") {
+		targetRepo = getRepository(c, targetRepo, config.Go, false)
+	}
 	details := createArtifactoryDetailsByFlags(c, true)
 
 	logGoVersion()
@@ -1398,117 +1795,201 @@ func pingCmd(c *cli.Context) {
 }
 
 func downloadCmd(c *cli.Context) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 1 || c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
-		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
-	}
-
 	var downloadSpec *spec.SpecFiles
-	if c.IsSet("spec") {
-		downloadSpec = getDownloadSpec(c)
+	if c.IsSet("plan-in") {
+		downloadSpec = getPlanSpec(c)
 	} else {
-		validateCommonContext(c)
-		downloadSpec = createDefaultDownloadSpec(c)
+		if c.NArg() > 0 && c.IsSet("spec") {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+		}
+		if !(c.NArg() == 1 || c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+			cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+		}
+		if c.IsSet("spec") {
+			downloadSpec = getDownloadSpec(c)
+		} else {
+			validateCommonContext(c)
+			downloadSpec = createDefaultDownloadSpec(c)
+		}
 	}
 
 	configuration := createDownloadConfiguration(c)
+	configuration.DetailedSummary = cliutils.CreateDetailedSummary(c, "download")
+	configuration.Plan = createPlan(c, configuration.ArtDetails)
+	configuration.DryRun = configuration.DryRun || configuration.Plan != nil
 	downloaded, failed, err := generic.Download(downloadSpec, configuration)
-	err = cliutils.PrintSummaryReport(downloaded, failed, err)
+	savePlan(c, configuration.Plan, failed, err)
+	err = cliutils.PrintDetailedSummaryReport(configuration.DetailedSummary, downloaded, failed, err)
 	cliutils.FailNoOp(err, downloaded, failed, isFailNoOp(c))
 }
 
-func uploadCmd(c *cli.Context) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
-		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
-	}
+func syncUploadCmd(c *cli.Context) {
+	syncSpec := getSyncSpec(c, true)
+	configuration := createSyncConfiguration(c)
+	synced, failed, err := generic.SyncUpload(syncSpec, configuration)
+	err = cliutils.PrintSummaryReport(synced, failed, err)
+	cliutils.FailNoOp(err, synced, failed, isFailNoOp(c))
+}
+
+func syncDownloadCmd(c *cli.Context) {
+	syncSpec := getSyncSpec(c, false)
+	configuration := createSyncConfiguration(c)
+	synced, failed, err := generic.SyncDownload(syncSpec, configuration)
+	err = cliutils.PrintSummaryReport(synced, failed, err)
+	cliutils.FailNoOp(err, synced, failed, isFailNoOp(c))
+}
 
+func uploadCmd(c *cli.Context) {
 	var uploadSpec *spec.SpecFiles
-	if c.IsSet("spec") {
-		uploadSpec = getFileSystemSpec(c, true)
+	if c.IsSet("plan-in") {
+		uploadSpec = getPlanSpec(c)
 	} else {
-		uploadSpec = createDefaultUploadSpec(c)
+		if c.NArg() > 0 && c.IsSet("spec") {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+		}
+		if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+			cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+		}
+		if c.IsSet("spec") {
+			uploadSpec = getFileSystemSpec(c, true)
+		} else {
+			uploadSpec = createDefaultUploadSpec(c)
+		}
 	}
 	configuration := createUploadConfiguration(c)
+	configuration.DetailedSummary = cliutils.CreateDetailedSummary(c, "upload")
+	configuration.Plan = createPlan(c, configuration.ArtDetails)
+	configuration.DryRun = configuration.DryRun || configuration.Plan != nil
 	uploaded, failed, err := generic.Upload(uploadSpec, configuration)
-	err = cliutils.PrintSummaryReport(uploaded, failed, err)
+	savePlan(c, configuration.Plan, failed, err)
+	err = cliutils.PrintDetailedSummaryReport(configuration.DetailedSummary, uploaded, failed, err)
 	cliutils.FailNoOp(err, uploaded, failed, isFailNoOp(c))
 }
 
 func moveCmd(c *cli.Context) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
-		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
-	}
-
 	var moveSpec *spec.SpecFiles
-	if c.IsSet("spec") {
-		moveSpec = getCopyMoveSpec(c)
+	var plan *generic.Plan
+	var plannedItems []rtclientutils.ResultItem
+	if c.IsSet("plan-in") {
+		plan, plannedItems = readPlan(c)
 	} else {
-		validateCommonContext(c)
-		moveSpec = createDefaultCopyMoveSpec(c)
+		if c.NArg() > 0 && c.IsSet("spec") {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+		}
+		if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+			cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+		}
+		if c.IsSet("spec") {
+			moveSpec = getCopyMoveSpec(c)
+		} else {
+			validateCommonContext(c)
+			moveSpec = createDefaultCopyMoveSpec(c)
+		}
 	}
 
 	configuration := createMoveConfiguration(c)
-	moveCount, failed, err := generic.Move(moveSpec, configuration)
-	err = cliutils.PrintSummaryReport(moveCount, failed, err)
+	configuration.DetailedSummary = cliutils.CreateDetailedSummary(c, "move")
+	configuration.Plan = createPlan(c, configuration.ArtDetails)
+	configuration.DryRun = configuration.DryRun || configuration.Plan != nil
+	var moveCount, failed int
+	var err error
+	if plan != nil {
+		moveCount, failed, err = generic.MovePlannedItems(plan, plannedItems, configuration)
+	} else {
+		moveCount, failed, err = generic.Move(moveSpec, configuration)
+	}
+	savePlan(c, configuration.Plan, failed, err)
+	err = cliutils.PrintDetailedSummaryReport(configuration.DetailedSummary, moveCount, failed, err)
 	cliutils.FailNoOp(err, moveCount, failed, isFailNoOp(c))
 }
 
 func copyCmd(c *cli.Context) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
-		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
-	}
-
 	var copySpec *spec.SpecFiles
-	if c.IsSet("spec") {
-		copySpec = getCopyMoveSpec(c)
+	var plan *generic.Plan
+	var plannedItems []rtclientutils.ResultItem
+	if c.IsSet("plan-in") {
+		plan, plannedItems = readPlan(c)
 	} else {
-		validateCommonContext(c)
-		copySpec = createDefaultCopyMoveSpec(c)
+		if c.NArg() > 0 && c.IsSet("spec") {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+		}
+		if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+			cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+		}
+		if c.IsSet("spec") {
+			copySpec = getCopyMoveSpec(c)
+		} else {
+			validateCommonContext(c)
+			copySpec = createDefaultCopyMoveSpec(c)
+		}
 	}
 
 	configuration := createCopyConfiguration(c)
-	copyCount, failed, err := generic.Copy(copySpec, configuration)
-	err = cliutils.PrintSummaryReport(copyCount, failed, err)
+	configuration.DetailedSummary = cliutils.CreateDetailedSummary(c, "copy")
+	configuration.Plan = createPlan(c, configuration.ArtDetails)
+	configuration.DryRun = configuration.DryRun || configuration.Plan != nil
+	var copyCount, failed int
+	var err error
+	if plan != nil {
+		copyCount, failed, err = generic.CopyPlannedItems(plan, plannedItems, configuration)
+	} else {
+		copyCount, failed, err = generic.Copy(copySpec, configuration)
+	}
+	savePlan(c, configuration.Plan, failed, err)
+	err = cliutils.PrintDetailedSummaryReport(configuration.DetailedSummary, copyCount, failed, err)
 	cliutils.FailNoOp(err, copyCount, failed, isFailNoOp(c))
 }
 
 func deleteCmd(c *cli.Context) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 1 || (c.NArg() == 0 && c.IsSet("spec"))) {
-		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
-	}
-
-	var deleteSpec *spec.SpecFiles
-	if c.IsSet("spec") {
-		deleteSpec = getDeleteSpec(c)
+	configuration := createDeleteConfiguration(c)
+	configuration.DetailedSummary = cliutils.CreateDetailedSummary(c, "delete")
+	var pathsToDelete []rtclientutils.ResultItem
+	if c.IsSet("plan-in") {
+		_, pathsToDelete = readPlan(c)
 	} else {
-		validateCommonContext(c)
-		deleteSpec = createDefaultDeleteSpec(c)
+		if c.NArg() > 0 && c.IsSet("spec") {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+		}
+		if !(c.NArg() == 1 || (c.NArg() == 0 && c.IsSet("spec"))) {
+			cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+		}
+		var deleteSpec *spec.SpecFiles
+		if c.IsSet("spec") {
+			deleteSpec = getDeleteSpec(c)
+		} else {
+			validateCommonContext(c)
+			deleteSpec = createDefaultDeleteSpec(c)
+		}
+		var err error
+		pathsToDelete, err = generic.GetPathsToDelete(deleteSpec, configuration)
+		cliutils.ExitOnErr(err)
+	}
+	if plan := createPlan(c, configuration.ArtDetails); plan != nil {
+		for _, item := range pathsToDelete {
+			plan.AddItem(item, "")
+		}
+		savePlan(c, plan, 0, nil)
+		return
 	}
-
-	configuration := createDeleteConfiguration(c)
-	pathsToDelete, err := generic.GetPathsToDelete(deleteSpec, configuration)
-	cliutils.ExitOnErr(err)
 	if c.Bool("quiet") || confirmDelete(pathsToDelete) {
 		success, failed, err := generic.DeleteFiles(pathsToDelete, configuration)
-		err = cliutils.PrintSummaryReport(success, failed, err)
+		err = cliutils.PrintDetailedSummaryReport(configuration.DetailedSummary, success, failed, err)
 		cliutils.FailNoOp(err, success, failed, isFailNoOp(c))
 	}
 }
 
+// Commands which don't print a summary by default, print the detailed summary only if it was requested.
+func printDetailedSummaryIfNeeded(detailedSummary *summary.Summary, err error) {
+	if detailedSummary == nil {
+		return
+	}
+	if err != nil {
+		cliutils.PrintDetailedSummaryReport(detailedSummary, 0, 1, err)
+		return
+	}
+	cliutils.PrintDetailedSummaryReport(detailedSummary, 1, 0, err)
+}
+
 func confirmDelete(pathsToDelete []rtclientutils.ResultItem) bool {
 	if len(pathsToDelete) < 1 {
 		return false
@@ -1545,17 +2026,22 @@ func searchCmd(c *cli.Context) {
 }
 
 func setPropsCmd(c *cli.Context) {
-	validatePropsCommand(c)
-	propertiesSpec, properties, artDetails := createPropsParams(c)
-	success, failed, err := generic.SetProps(propertiesSpec, properties, getThreadsCount(c), artDetails)
-	err = cliutils.PrintSummaryReport(success, failed, err)
+	resultItems, properties, artDetails := getPropsItems(c)
+	if savePropsPlan(c, resultItems, properties, artDetails) {
+		return
+	}
+	detailedSummary := cliutils.CreateDetailedSummary(c, "set-props")
+	success, failed, err := generic.SetPropsOnItems(resultItems, properties, getThreadsCount(c), artDetails, detailedSummary)
+	err = cliutils.PrintDetailedSummaryReport(detailedSummary, success, failed, err)
 	cliutils.FailNoOp(err, success, failed, isFailNoOp(c))
 }
 
 func deletePropsCmd(c *cli.Context) {
-	validatePropsCommand(c)
-	propertiesSpec, properties, artDetails := createPropsParams(c)
-	success, failed, err := generic.DeleteProps(propertiesSpec, properties, getThreadsCount(c), artDetails)
+	resultItems, properties, artDetails := getPropsItems(c)
+	if savePropsPlan(c, resultItems, properties, artDetails) {
+		return
+	}
+	success, failed, err := generic.DeletePropsOnItems(resultItems, properties, getThreadsCount(c), artDetails)
 	err = cliutils.PrintSummaryReport(success, failed, err)
 	cliutils.FailNoOp(err, success, failed, isFailNoOp(c))
 }
@@ -1563,7 +2049,29 @@ func deletePropsCmd(c *cli.Context) {
 func buildPublishCmd(c *cli.Context) {
 	validateBuildInfoArgument(c)
 	configuration, artDetails := createBuildInfoConfiguration(c)
-	err := buildinfo.Publish(c.Args().Get(0), c.Args().Get(1), configuration, artDetails)
+	detailedSummary := cliutils.CreateDetailedSummary(c, "build-publish")
+	err := buildinfo.Publish(c.Args().Get(0), c.Args().Get(1), configuration, artDetails, detailedSummary)
+	printDetailedSummaryIfNeeded(detailedSummary, err)
+	cliutils.ExitOnErr(err)
+}
+
+func buildExportCmd(c *cli.Context) {
+	if c.NArg() != 3 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	configuration := createBuildExportConfiguration(c)
+	// The build-info is exported on machines which may not have access to Artifactory, so the server configuration isn't used.
+	artDetails := &config.ArtifactoryDetails{User: c.String("user")}
+	err := buildinfo.Export(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2), configuration, artDetails)
+	cliutils.ExitOnErr(err)
+}
+
+func buildImportCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	configuration := createBuildImportConfiguration(c)
+	err := buildinfo.Import(configuration)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1577,7 +2085,11 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 
 	var dependenciesSpec *spec.SpecFiles
 	if c.IsSet("spec") {
-		dependenciesSpec = getFileSystemSpec(c, false)
+		if c.Bool("from-rt") {
+			dependenciesSpec = getSearchSpec(c)
+		} else {
+			dependenciesSpec = getFileSystemSpec(c, false)
+		}
 	} else {
 		dependenciesSpec = createDefaultBuildAddDependenciesSpec(c)
 	}
@@ -1594,6 +2106,12 @@ func buildCollectEnvCmd(c *cli.Context) {
 	cliutils.ExitOnErr(err)
 }
 
+func buildCollectCiCmd(c *cli.Context) {
+	validateBuildInfoArgument(c)
+	err := buildinfo.CollectCi(c.Args().Get(0), c.Args().Get(1))
+	cliutils.ExitOnErr(err)
+}
+
 func buildAddGitCmd(c *cli.Context) {
 	if c.NArg() > 3 || c.NArg() < 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
@@ -1602,7 +2120,33 @@ func buildAddGitCmd(c *cli.Context) {
 	if c.NArg() == 3 {
 		dotGitPath = c.Args().Get(2)
 	}
-	err := buildinfo.AddGit(c.Args().Get(0), c.Args().Get(1), dotGitPath)
+	configuration := &buildinfo.AddGitConfiguration{BuildName: c.Args().Get(0), BuildNumber: c.Args().Get(1), DotGitPath: dotGitPath}
+	if c.Bool("changed-files") {
+		configuration.ArtDetails = createArtifactoryDetailsByFlags(c, true)
+	}
+	err := buildinfo.AddGit(configuration)
+	cliutils.ExitOnErr(err)
+}
+
+func buildAddIssuesCmd(c *cli.Context) {
+	if c.NArg() > 3 || c.NArg() < 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	if c.String("config") == "" {
+		cliutils.PrintHelpAndExitWithError("The --config option is mandatory.", c)
+	}
+	dotGitPath := ""
+	if c.NArg() == 3 {
+		dotGitPath = c.Args().Get(2)
+	}
+	configuration := &buildinfo.AddIssuesConfiguration{
+		BuildName:      c.Args().Get(0),
+		BuildNumber:    c.Args().Get(1),
+		ConfigFilePath: c.String("config"),
+		DotGitPath:     dotGitPath,
+		ArtDetails:     createArtifactoryDetailsByFlags(c, true),
+	}
+	err := buildinfo.AddIssues(configuration)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1647,6 +2191,105 @@ func buildDiscardCmd(c *cli.Context) {
 	cliutils.ExitOnErr(err)
 }
 
+func buildDiffCmd(c *cli.Context) {
+	if c.NArg() != 3 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	format := c.String("format")
+	if format == "" {
+		format = buildinfo.DiffFormatTable
+	}
+	if format != buildinfo.DiffFormatTable && format != buildinfo.DiffFormatJson {
+		cliutils.PrintHelpAndExitWithError("The --format option accepts 'table' or 'json'.", c)
+	}
+	// The Artifactory details are optional, since the compared builds may have been collected locally.
+	artDetails := createArtifactoryDetails(c, true)
+	diff, err := buildinfo.Diff(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2), artDetails)
+	cliutils.ExitOnErr(err)
+	err = buildinfo.PrintDiff(diff, format)
+	cliutils.ExitOnErr(err)
+}
+
+func specValidateCmd(c *cli.Context) {
+	if c.Bool("schema") {
+		if c.NArg() != 0 {
+			cliutils.PrintHelpAndExitWithError("No arguments should be sent when the schema option is used.", c)
+		}
+		log.Output(spec.Schema)
+		return
+	}
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	validationErrors, err := spec.ValidateSpecFile(c.Args().Get(0), c.String("command"), cliutils.SpecVarsStringToMap(c.String("spec-vars")))
+	cliutils.ExitOnErr(err)
+	for _, validationError := range validationErrors {
+		log.Error(validationError.String())
+	}
+	if len(validationErrors) > 0 {
+		cliutils.ExitOnErr(errors.New("The File Spec has " + strconv.Itoa(len(validationErrors)) + " errors."))
+	}
+	log.Info("The File Spec is valid.")
+}
+
+func buildSbomCmd(c *cli.Context) {
+	validateBuildInfoArgument(c)
+	format := c.String("format")
+	if format != sbom.SpdxJson && format != sbom.CycloneDxJson {
+		cliutils.PrintHelpAndExitWithError("The --format option is mandatory and accepts '"+sbom.SpdxJson+"' or '"+sbom.CycloneDxJson+"'.", c)
+	}
+	// The Artifactory details are optional, since the build may have been collected locally.
+	artDetails := createArtifactoryDetails(c, true)
+	err := buildinfo.Sbom(c.Args().Get(0), c.Args().Get(1), format, c.String("output"), artDetails)
+	cliutils.ExitOnErr(err)
+}
+
+func buildListCmd(c *cli.Context) {
+	if c.NArg() != 0 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	err := buildinfo.List()
+	cliutils.ExitOnErr(err)
+}
+
+func buildStoreConfigCmd(c *cli.Context) {
+	if c.NArg() != 0 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	var expiryDays *int
+	if c.IsSet("expiry-days") {
+		days, err := strconv.Atoi(c.String("expiry-days"))
+		if err != nil {
+			cliutils.PrintHelpAndExitWithError("The --expiry-days option should have a numeric value.", c)
+		}
+		expiryDays = &days
+	}
+	settings, err := buildinfo.ConfigStore(c.String("dir"), expiryDays)
+	cliutils.ExitOnErr(err)
+	result, err := json.Marshal(settings)
+	cliutils.ExitOnErr(err)
+	log.Output(string(clientutils.IndentJson(result)))
+}
+
+func cacheCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	switch c.Args().Get(0) {
+	case "clean":
+		err := generic.CacheClean()
+		cliutils.ExitOnErr(err)
+	case "stats":
+		stats, err := generic.CacheStats()
+		cliutils.ExitOnErr(err)
+		result, err := json.Marshal(stats)
+		cliutils.ExitOnErr(err)
+		log.Output(string(clientutils.IndentJson(result)))
+	default:
+		cliutils.PrintHelpAndExitWithError("Unknown argument: "+c.Args().Get(0), c)
+	}
+}
+
 func gitLfsCleanCmd(c *cli.Context) {
 	if c.NArg() > 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
@@ -1724,6 +2367,8 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 	details = new(config.ArtifactoryDetails)
 	details.Url = c.String("url")
 	details.ApiKey = c.String("apikey")
+	details.AccessToken = c.String("access-token")
+	details.RefreshToken = c.String("refresh-token")
 	details.User = c.String("user")
 	details.Password = c.String("password")
 	details.SshKeyPath = c.String("ssh-key-path")
@@ -1737,6 +2382,12 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 	}
 
 	if includeConfig && !credentialsChanged(details) {
+		if details.ServerId == "" {
+			// The server of the project overrides the default server.
+			projectConfig, err := config.GetProjectConfig()
+			cliutils.ExitOnErr(err)
+			details.ServerId = projectConfig.ServerId
+		}
 		confDetails, err := commands.GetConfig(details.ServerId)
 		cliutils.ExitOnErr(err)
 
@@ -1748,6 +2399,10 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 			if details.ApiKey == "" {
 				details.ApiKey = confDetails.ApiKey
 			}
+			if details.AccessToken == "" {
+				details.AccessToken = confDetails.AccessToken
+				details.RefreshToken = confDetails.RefreshToken
+			}
 			if details.User == "" {
 				details.User = confDetails.User
 			}
@@ -1765,11 +2420,11 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 
 func credentialsChanged(details *config.ArtifactoryDetails) bool {
 	return details.Url != "" || details.User != "" || details.Password != "" ||
-		details.ApiKey != "" || details.SshKeyPath != "" || details.SshAuthHeaderSet()
+		details.ApiKey != "" || details.AccessToken != "" || details.SshKeyPath != "" || details.SshAuthHeaderSet()
 }
 
 func isAuthMethodSet(details *config.ArtifactoryDetails) bool {
-	return (details.User != "" && details.Password != "") || details.SshKeyPath != "" || details.ApiKey != ""
+	return (details.User != "" && details.Password != "") || details.SshKeyPath != "" || details.ApiKey != "" || details.AccessToken != ""
 }
 
 func getDebFlag(c *cli.Context) (deb string) {
@@ -1903,10 +2558,15 @@ func getSearchSpec(c *cli.Context) (searchSpec *spec.SpecFiles) {
 }
 
 func createBuildInfoConfiguration(c *cli.Context) (flags *buildinfocmd.Configuration, artDetails *config.ArtifactoryDetails) {
-	flags = new(buildinfocmd.Configuration)
+	flags = createBuildExportConfiguration(c)
 	artDetails = createArtifactoryDetailsByFlags(c, true)
-	flags.BuildUrl = c.String("build-url")
 	flags.DryRun = c.Bool("dry-run")
+	return
+}
+
+func createBuildExportConfiguration(c *cli.Context) (flags *buildinfocmd.Configuration) {
+	flags = new(buildinfocmd.Configuration)
+	flags.BuildUrl = c.String("build-url")
 	flags.EnvInclude = c.String("env-include")
 	flags.EnvExclude = c.String("env-exclude")
 	if len(flags.EnvInclude) == 0 {
@@ -1914,11 +2574,21 @@ func createBuildInfoConfiguration(c *cli.Context) (flags *buildinfocmd.Configura
 	}
 	// Allow to use `env-exclude=""` and get no filters
 	if !c.IsSet("env-exclude") {
-		flags.EnvExclude = "*password*;*secret*;*key*;*token*"
+		flags.EnvExclude = buildinfo.DefaultEnvExclude
 	}
 	return
 }
 
+func createBuildImportConfiguration(c *cli.Context) (importConfiguration *buildinfo.BuildImportConfiguration) {
+	importConfiguration = new(buildinfo.BuildImportConfiguration)
+	importConfiguration.FilePath = c.Args().Get(0)
+	importConfiguration.BuildName = c.String("build-name")
+	importConfiguration.BuildNumber = c.String("build-number")
+	importConfiguration.DryRun = c.Bool("dry-run")
+	importConfiguration.ArtDetails = createArtifactoryDetailsByFlags(c, true)
+	return
+}
+
 func createBuildPromoteConfiguration(c *cli.Context) (promoteConfiguration *buildinfo.BuildPromotionConfiguration) {
 	promotionParamsImpl := new(services.PromotionParamsImpl)
 	promotionParamsImpl.Comment = c.String("comment")
@@ -2027,11 +2697,14 @@ func createDownloadConfiguration(c *cli.Context) (downloadConfiguration *generic
 	downloadConfiguration.MinSplitSize = getMinSplit(c)
 	downloadConfiguration.SplitCount = getSplitCount(c)
 	downloadConfiguration.Threads = getThreadsCount(c)
-	downloadConfiguration.BuildName = c.String("build-name")
+	downloadConfiguration.BuildName = getBuildName(c)
 	downloadConfiguration.BuildNumber = c.String("build-number")
+	downloadConfiguration.Module = c.String("module")
 	downloadConfiguration.Retries = getRetries(c)
 	downloadConfiguration.Symlink = true
+	downloadConfiguration.Cache = c.Bool("cache")
 	validateBuildParams(downloadConfiguration.BuildName, downloadConfiguration.BuildNumber)
+	validateModuleParam(downloadConfiguration.Module, downloadConfiguration.BuildName)
 	downloadConfiguration.ArtDetails = createArtifactoryDetailsByFlags(c, true)
 	return
 }
@@ -2041,6 +2714,55 @@ func createBuildAddDependenciesConfiguration(c *cli.Context) (buildAddDependenci
 	buildAddDependenciesConfiguration.DryRun = c.Bool("dry-run")
 	buildAddDependenciesConfiguration.BuildName = c.Args().Get(0)
 	buildAddDependenciesConfiguration.BuildNumber = c.Args().Get(1)
+	buildAddDependenciesConfiguration.Module = c.String("module")
+	buildAddDependenciesConfiguration.Scopes = getScopes(c)
+	if c.Bool("from-rt") {
+		buildAddDependenciesConfiguration.ArtDetails = createArtifactoryDetailsByFlags(c, true)
+	}
+	return
+}
+
+func getScopes(c *cli.Context) []string {
+	var scopes []string
+	for _, scope := range strings.Split(c.String("scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// For sync-upload, the spec pattern is a local directory and the target is an Artifactory path.
+// For sync-download, the spec pattern is an Artifactory path and the target is a local directory.
+func getSyncSpec(c *cli.Context, isUpload bool) (syncSpec *spec.SpecFiles) {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	if !c.IsSet("spec") {
+		syncSpec = spec.NewBuilder().
+			Pattern(c.Args().Get(0)).
+			Props(c.String("props")).
+			Recursive(c.BoolT("recursive")).
+			Target(c.Args().Get(1)).
+			BuildSpec()
+	} else {
+		var err error
+		syncSpec, err = spec.CreateSpecFromFile(c.String("spec"), cliutils.SpecVarsStringToMap(c.String("spec-vars")))
+		cliutils.ExitOnErr(err)
+	}
+	for i := 0; i < len(syncSpec.Files); i++ {
+		if isUpload {
+			syncSpec.Get(i).Target = strings.TrimPrefix(syncSpec.Get(i).Target, "/")
+		} else {
+			syncSpec.Get(i).Pattern = strings.TrimPrefix(syncSpec.Get(i).Pattern, "/")
+		}
+		overrideFieldsIfSet(syncSpec.Get(i), c)
+	}
+	err := spec.ValidateSpec(syncSpec.Files, true)
+	cliutils.ExitOnErr(err)
 	return
 }
 
@@ -2107,13 +2829,18 @@ func fixWinDownloadFilesPath(uploadSpec *spec.SpecFiles) {
 
 func createUploadConfiguration(c *cli.Context) (uploadConfiguration *generic.UploadConfiguration) {
 	uploadConfiguration = new(generic.UploadConfiguration)
-	buildName := c.String("build-name")
+	buildName := getBuildName(c)
 	buildNumber := c.String("build-number")
+	module := c.String("module")
 	validateBuildParams(buildName, buildNumber)
+	validateModuleParam(module, buildName)
 	uploadConfiguration.BuildName = buildName
 	uploadConfiguration.BuildNumber = buildNumber
+	uploadConfiguration.Module = module
+	uploadConfiguration.ArtifactType = c.String("artifact-type")
 	uploadConfiguration.DryRun = c.Bool("dry-run")
 	uploadConfiguration.Symlink = c.Bool("symlinks")
+	uploadConfiguration.Resume = c.Bool("resume")
 	uploadConfiguration.Retries = getRetries(c)
 	uploadConfiguration.Threads = getThreadsCount(c)
 	uploadConfiguration.Deb = getDebFlag(c)
@@ -2121,17 +2848,30 @@ func createUploadConfiguration(c *cli.Context) (uploadConfiguration *generic.Upl
 	return
 }
 
+func createSyncConfiguration(c *cli.Context) (syncConfiguration *generic.SyncConfiguration) {
+	syncConfiguration = new(generic.SyncConfiguration)
+	syncConfiguration.DryRun = c.Bool("dry-run")
+	syncConfiguration.Delete = c.Bool("delete")
+	syncConfiguration.Retries = getRetries(c)
+	syncConfiguration.Threads = getThreadsCount(c)
+	syncConfiguration.ArtDetails = createArtifactoryDetailsByFlags(c, true)
+	return
+}
+
 func createBuildToolConfiguration(c *cli.Context) (buildConfigConfiguration *utils.BuildConfiguration) {
 	buildConfigConfiguration = new(utils.BuildConfiguration)
-	buildConfigConfiguration.BuildName = c.String("build-name")
+	buildConfigConfiguration.BuildName = getBuildName(c)
 	buildConfigConfiguration.BuildNumber = c.String("build-number")
 	validateBuildParams(buildConfigConfiguration.BuildName, buildConfigConfiguration.BuildNumber)
+	projectConfig, err := config.GetProjectConfig()
+	cliutils.ExitOnErr(err)
+	buildConfigConfiguration.ProjectConfig = projectConfig
 	return
 }
 
 func createNpmConfiguration(c *cli.Context) (npmConfiguration *npmutils.CliConfiguration) {
 	npmConfiguration = new(npmutils.CliConfiguration)
-	npmConfiguration.BuildName = c.String("build-name")
+	npmConfiguration.BuildName = getBuildName(c)
 	npmConfiguration.BuildNumber = c.String("build-number")
 	validateBuildParams(npmConfiguration.BuildName, npmConfiguration.BuildNumber)
 	npmConfiguration.NpmArgs = c.String("npm-args")
@@ -2149,10 +2889,27 @@ func createConfigCommandConfiguration(c *cli.Context) (configCommandConfiguratio
 	return
 }
 
+// Returns the passphrase of the --passphrase option, or of the JFROG_CLI_SERVER_TOKEN_PASSPHRASE environment variable if the option isn't set.
+// If neither is set and prompt is true, the passphrase is read from the console.
+func getServerTokenPassphrase(c *cli.Context, prompt bool, caption string) string {
+	if passphrase := c.String("passphrase"); passphrase != "" {
+		return passphrase
+	}
+	if passphrase := os.Getenv(config.ServerTokenPassphraseEnv); passphrase != "" || !prompt {
+		return passphrase
+	}
+	passphrase, err := ioutils.ScanPasswordFromConsole(caption)
+	cliutils.ExitOnErr(err)
+	return passphrase
+}
+
 func validateConfigFlags(configCommandConfiguration *commands.ConfigCommandConfiguration) {
 	if !configCommandConfiguration.Interactive && configCommandConfiguration.ArtDetails.Url == "" {
 		cliutils.ExitOnErr(errors.New("The --url option is mandatory when the --interactive option is set to false"))
 	}
+	if configCommandConfiguration.ArtDetails.RefreshToken != "" && configCommandConfiguration.ArtDetails.AccessToken == "" {
+		cliutils.ExitOnErr(errors.New("The --refresh-token option is allowed only with the --access-token option"))
+	}
 }
 
 // If `fieldName` exist in the cli args, read it to `field` as a string.
@@ -2194,12 +2951,43 @@ func validateCommonContext(c *cli.Context) {
 	}
 }
 
+// Returns the --build-name option. If only the --build-number option is set, the build name of the project configuration is returned.
+func getBuildName(c *cli.Context) string {
+	buildName := c.String("build-name")
+	if buildName == "" && c.String("build-number") != "" {
+		projectConfig, err := config.GetProjectConfig()
+		cliutils.ExitOnErr(err)
+		buildName = projectConfig.Build.Name
+	}
+	return buildName
+}
+
+// Returns the repository argument. If it isn't set, the repository of the package manager in the project configuration is returned.
+func getRepository(c *cli.Context, repository, packageManager string, deploy bool) string {
+	if repository != "" {
+		return repository
+	}
+	projectConfig, err := config.GetProjectConfig()
+	cliutils.ExitOnErr(err)
+	repository = projectConfig.GetRepository(packageManager, deploy)
+	if repository == "" {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	return repository
+}
+
 func validateBuildParams(buildName, buildNumber string) {
 	if (buildName == "" && buildNumber != "") || (buildName != "" && buildNumber == "") {
 		cliutils.ExitOnErr(errors.New("The build-name and build-number options cannot be sent separately."))
 	}
 }
 
+func validateModuleParam(module, buildName string) {
+	if module != "" && buildName == "" {
+		cliutils.ExitOnErr(errors.New("The build-name and build-number options are mandatory when the module option is provided."))
+	}
+}
+
 func overrideFieldsIfSet(spec *spec.File, c *cli.Context) {
 	overrideArrayIfSet(&spec.ExcludePatterns, c, "exclude-patterns")
 	overrideArrayIfSet(&spec.SortBy, c, "sort-by")
@@ -2228,6 +3016,79 @@ func isFailNoOp(context *cli.Context) bool {
 	return context.Bool("fail-no-op")
 }
 
+// Returns the items whose properties are set or deleted, which are found by the command arguments or read from the plan of the plan-in option.
+func getPropsItems(c *cli.Context) (resultItems []rtclientutils.ResultItem, properties string, artDetails *config.ArtifactoryDetails) {
+	if c.IsSet("plan-in") {
+		plan, resultItems := readPlan(c)
+		return resultItems, plan.Props, createArtifactoryDetailsByFlags(c, true)
+	}
+	validatePropsCommand(c)
+	propertiesSpec, properties, artDetails := createPropsParams(c)
+	resultItems, err := generic.GetPropsItems(propertiesSpec, artDetails)
+	cliutils.ExitOnErr(err)
+	return resultItems, properties, artDetails
+}
+
+// Writes the plan of the plan-out option, and returns true if it was written.
+func savePropsPlan(c *cli.Context, resultItems []rtclientutils.ResultItem, properties string, artDetails *config.ArtifactoryDetails) bool {
+	plan := createPlan(c, artDetails)
+	if plan == nil {
+		return false
+	}
+	plan.Props = properties
+	for _, item := range resultItems {
+		plan.AddItem(item, "")
+	}
+	savePlan(c, plan, 0, nil)
+	return true
+}
+
+// Returns a new plan for the command if the plan-out option is set, or nil otherwise.
+func createPlan(c *cli.Context, artDetails *config.ArtifactoryDetails) *generic.Plan {
+	if !c.IsSet("plan-out") {
+		return nil
+	}
+	if c.IsSet("plan-in") {
+		cliutils.PrintHelpAndExitWithError("The plan-out and plan-in options cannot be used together.", c)
+	}
+	return generic.NewPlan(c.Command.Name, artDetails.Url)
+}
+
+// Writes the plan to the path of the plan-out option, unless the command failed to resolve all of its files.
+func savePlan(c *cli.Context, plan *generic.Plan, failed int, err error) {
+	if plan == nil {
+		return
+	}
+	if err != nil || failed > 0 {
+		log.Error("The plan was not written, since the command failed to resolve all of its files.")
+		return
+	}
+	cliutils.ExitOnErr(plan.Save(c.String("plan-out")))
+	log.Info("The plan of", strconv.Itoa(len(plan.Operations)), "operations was written to", c.String("plan-out")+".")
+}
+
+// Reads the plan of the plan-in option, which replaces the arguments and the spec of the command.
+// Exits if any of the planned sources were changed since the plan was created. Returns the plan and the Artifactory items of its sources.
+func readPlan(c *cli.Context) (*generic.Plan, []rtclientutils.ResultItem) {
+	if c.NArg() > 0 || c.IsSet("spec") {
+		cliutils.PrintHelpAndExitWithError("No arguments or spec should be sent when the plan-in option is used.", c)
+	}
+	artDetails := createArtifactoryDetailsByFlags(c, true)
+	plan, err := generic.ReadPlan(c.String("plan-in"), c.Command.Name, artDetails.Url)
+	cliutils.ExitOnErr(err)
+	resultItems, err := generic.VerifyPlan(plan, artDetails)
+	cliutils.ExitOnErr(err)
+	return plan, resultItems
+}
+
+// Returns the spec of the plan of the plan-in option, which transfers exactly the planned files.
+func getPlanSpec(c *cli.Context) *spec.SpecFiles {
+	plan, _ := readPlan(c)
+	planSpec, err := plan.ToSpec()
+	cliutils.ExitOnErr(err)
+	return planSpec
+}
+
 func createPropsParams(c *cli.Context) (propertiesSpec *spec.SpecFiles, properties string, artDetails *config.ArtifactoryDetails) {
 	propertiesSpec = createDefaultPropertiesSpec(c)
 	properties = c.Args()[1]
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
 	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
@@ -23,23 +24,75 @@ func AddDependencies(dependenciesSpec *spec.SpecFiles, configuration *AddDepende
 		}
 	}
 
-	dependenciesPaths, errorOccurred := collectDependenciesBySpec(dependenciesSpec)
-	dependenciesDetails, errorOccurred, failures := collectDependenciesChecksums(dependenciesPaths, errorOccurred)
+	var dependencies []utils.Dependency
+	errorOccurred, failures := false, 0
+	if configuration.FromRt {
+		dependencies, errorOccurred = collectRemoteDependencies(dependenciesSpec, configuration)
+	} else {
+		var dependenciesPaths map[string]string
+		var dependenciesDetails map[string]*fileutils.FileDetails
+		dependenciesPaths, errorOccurred = collectDependenciesBySpec(dependenciesSpec)
+		dependenciesDetails, errorOccurred, failures = collectDependenciesChecksums(dependenciesPaths, errorOccurred)
+		dependencies = convertFileInfoToDependencies(dependenciesDetails, configuration.Scopes)
+	}
 	if !configuration.DryRun {
-		err = saveDependenciesToFileSystem(dependenciesDetails, configuration)
+		err = saveDependenciesToFileSystem(dependencies, configuration)
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
 			// mark all as failures and clean the succeeded
-			failures += len(dependenciesDetails)
-			dependenciesDetails = make(map[string]*fileutils.FileDetails)
+			failures += len(dependencies)
+			dependencies = nil
 		}
 	}
 	if errorOccurred {
 		err = errors.New("Build Add Dependencies command finished with errors. Please review the logs.")
 	}
 
-	return len(dependenciesDetails), failures, err
+	return len(dependencies), failures, err
+}
+
+// Searches the dependencies in Artifactory, and collects their details without downloading them.
+func collectRemoteDependencies(dependenciesSpec *spec.SpecFiles, configuration *AddDependenciesConfiguration) ([]utils.Dependency, bool) {
+	servicesManager, err := utils.CreateServiceManager(configuration.ArtDetails, false)
+	if err != nil {
+		log.Error(err)
+		return nil, true
+	}
+	errorOccurred := false
+	var dependencies []utils.Dependency
+	for i := 0; i < len(dependenciesSpec.Files); i++ {
+		params, err := dependenciesSpec.Get(i).ToArtifatorySearchParams()
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		resultItems, err := servicesManager.Search(specutils.SearchParams{ArtifactoryCommonParams: params})
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		for _, resultItem := range resultItems {
+			if resultItem.Type == "folder" {
+				continue
+			}
+			log.Info("Adding dependency:", resultItem.GetItemRelativePath())
+			dependencies = append(dependencies, convertResultItemToDependency(resultItem, configuration.Scopes))
+		}
+	}
+	return dependencies, errorOccurred
+}
+
+func convertResultItemToDependency(resultItem specutils.ResultItem, scopes []string) utils.Dependency {
+	dependency := utils.Dependency{Dependency: buildinfo.Dependency{Checksum: &buildinfo.Checksum{}}}
+	dependency.Id = resultItem.Name
+	dependency.Sha1 = resultItem.Actual_Sha1
+	dependency.Md5 = resultItem.Actual_Md5
+	dependency.Scopes = scopes
+	dependency.Path = resultItem.GetItemRelativePath()
+	return dependency
 }
 
 func collectDependenciesChecksums(dependenciesPaths map[string]string, errorOccurred bool) (map[string]*fileutils.FileDetails, bool, int) {
@@ -156,22 +209,24 @@ func collectPatternMatchingFiles(addDepsParams *specutils.ArtifactoryCommonParam
 	return result, nil
 }
 
-func saveDependenciesToFileSystem(files map[string]*fileutils.FileDetails, configuration *AddDependenciesConfiguration) error {
-	log.Debug("Saving", strconv.Itoa(len(files)), "dependencies.")
-	populateFunc := func(partial *buildinfo.Partial) {
-		partial.Dependencies = convertFileInfoToDependencies(files)
+func saveDependenciesToFileSystem(dependencies []utils.Dependency, configuration *AddDependenciesConfiguration) error {
+	log.Debug("Saving", strconv.Itoa(len(dependencies)), "dependencies.")
+	populateFunc := func(partial *utils.Partial) {
+		partial.Dependencies = dependencies
+		partial.ModuleId = configuration.Module
 	}
 	return utils.SavePartialBuildInfo(configuration.BuildName, configuration.BuildNumber, populateFunc)
 }
 
-func convertFileInfoToDependencies(files map[string]*fileutils.FileDetails) []buildinfo.Dependency {
-	var buildDependencies []buildinfo.Dependency
+func convertFileInfoToDependencies(files map[string]*fileutils.FileDetails, scopes []string) []utils.Dependency {
+	var buildDependencies []utils.Dependency
 	for filePath, fileInfo := range files {
-		dependency := buildinfo.Dependency{Checksum: &buildinfo.Checksum{}}
+		dependency := utils.Dependency{Dependency: buildinfo.Dependency{Checksum: &buildinfo.Checksum{}}}
 		dependency.Md5 = fileInfo.Checksum.Md5
 		dependency.Sha1 = fileInfo.Checksum.Sha1
 		filename, _ := fileutils.GetFileAndDirFromPath(filePath)
 		dependency.Id = filename
+		dependency.Scopes = scopes
 		buildDependencies = append(buildDependencies, dependency)
 	}
 	return buildDependencies
@@ -180,5 +235,10 @@ func convertFileInfoToDependencies(files map[string]*fileutils.FileDetails) []bu
 type AddDependenciesConfiguration struct {
 	BuildName   string
 	BuildNumber string
+	Module      string
+	Scopes      []string
 	DryRun      bool
+	// Search the dependencies in Artifactory instead of the local file system.
+	FromRt     bool
+	ArtDetails *config.ArtifactoryDetails
 }
@@ -0,0 +1,53 @@
+package buildinfo
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollectRemoteDependencies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/api/search/aql") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"results":[
+			{"repo":"generic-local","path":"dir","name":"a.zip","type":"file","actual_sha1":"sha1","actual_md5":"md5"},
+			{"repo":"generic-local","path":".","name":"b.zip","type":"file","actual_sha1":"sha1b","actual_md5":"md5b"}
+		]}`)
+	}))
+	defer ts.Close()
+
+	configuration := &AddDependenciesConfiguration{
+		Scopes:     []string{"compile"},
+		FromRt:     true,
+		ArtDetails: &config.ArtifactoryDetails{Url: ts.URL + "/"},
+	}
+	dependenciesSpec := spec.NewBuilder().Pattern("generic-local/*.zip").Recursive(true).BuildSpec()
+	dependencies, errorOccurred := collectRemoteDependencies(dependenciesSpec, configuration)
+	if errorOccurred {
+		t.Fatal("Failed collecting the dependencies from Artifactory.")
+	}
+	if len(dependencies) != 2 {
+		t.Fatal("Expected two dependencies, got:", dependencies)
+	}
+	expectedPaths := []string{"generic-local/dir/a.zip", "generic-local/b.zip"}
+	for i, dependency := range dependencies {
+		if dependency.Path != expectedPaths[i] {
+			t.Errorf("Expected the dependency path to be %s, got %s.", expectedPaths[i], dependency.Path)
+		}
+		if !reflect.DeepEqual(dependency.Scopes, configuration.Scopes) {
+			t.Error("Unexpected dependency scopes:", dependency.Scopes)
+		}
+	}
+	if dependencies[0].Id != "a.zip" || dependencies[0].Sha1 != "sha1" || dependencies[0].Md5 != "md5" {
+		t.Error("Unexpected dependency details:", dependencies[0])
+	}
+}
@@ -3,17 +3,22 @@ package buildinfo
 import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/git"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"os"
+	"strings"
 )
 
-func AddGit(buildName, buildNumber, dotGitPath string) error {
+func AddGit(configuration *AddGitConfiguration) error {
 	log.Info("Collecting git revision and remote url...")
+	buildName, buildNumber := configuration.BuildName, configuration.BuildNumber
 	err := utils.SaveBuildGeneralDetails(buildName, buildNumber)
 	if err != nil {
 		return err
 	}
+	dotGitPath := configuration.DotGitPath
 	if dotGitPath == "" {
 		dotGitPath, err = os.Getwd()
 		if err != nil {
@@ -25,12 +30,14 @@ func AddGit(buildName, buildNumber, dotGitPath string) error {
 	if err != nil {
 		return err
 	}
+	vcsDetails := collectVcsDetails(gitManager, configuration)
 
-	populateFunc := func(partial *buildinfo.Partial) {
+	populateFunc := func(partial *utils.Partial) {
 		partial.Vcs = &buildinfo.Vcs{
 			Url:      gitManager.GetUrl(),
 			Revision: gitManager.GetRevision(),
 		}
+		partial.VcsDetails = vcsDetails
 	}
 
 	err = utils.SavePartialBuildInfo(buildName, buildNumber, populateFunc)
@@ -40,3 +47,131 @@ func AddGit(buildName, buildNumber, dotGitPath string) error {
 	log.Info("Collected git revision and remote url for", buildName+"/"+buildNumber+".")
 	return nil
 }
+
+// The details read from the git objects are optional, so failing to read them only produces a warning.
+func collectVcsDetails(gitManager gitDetailsReader, configuration *AddGitConfiguration) *utils.Vcs {
+	vcsDetails := &utils.Vcs{Url: gitManager.GetUrl(), Revision: gitManager.GetRevision(), Branch: gitManager.GetBranch()}
+	if commit, err := gitManager.ReadCommit(); err == nil {
+		vcsDetails.Message, vcsDetails.Author = commit.Message, commit.Author
+	} else {
+		log.Warn("Could not read the details of the git commit:", err.Error())
+	}
+	if dirty, err := gitManager.IsDirty(); err == nil {
+		vcsDetails.Dirty = dirty
+	} else {
+		log.Warn("Could not read the status of the git working tree:", err.Error())
+	}
+	if submodules, err := gitManager.GetSubmodules(); err == nil {
+		for _, submodule := range submodules {
+			vcsDetails.Submodules = append(vcsDetails.Submodules, utils.VcsSubmodule{Path: submodule.Path, Url: submodule.Url, Revision: submodule.Revision})
+		}
+	} else {
+		log.Warn("Could not read the git submodules:", err.Error())
+	}
+	if configuration.ArtDetails != nil {
+		vcsDetails.ChangedFiles = collectChangedFiles(gitManager, configuration)
+	}
+	return vcsDetails
+}
+
+// Returns the files changed since the revision of the previous build published to Artifactory.
+func collectChangedFiles(gitManager gitDetailsReader, configuration *AddGitConfiguration) []string {
+	previousRevision, err := getPreviousBuildRevision(configuration.BuildName, configuration.BuildNumber, gitManager.GetUrl(), configuration.ArtDetails)
+	if err != nil {
+		log.Warn("Could not get the revision of the previous build:", err.Error())
+		return nil
+	}
+	if previousRevision == "" {
+		log.Info("The previous build has no git revision, so the changed files aren't collected.")
+		return nil
+	}
+	changedFiles, err := gitManager.GetChangedFiles(previousRevision)
+	if err != nil {
+		// The revision may be missing from shallow clones.
+		log.Warn("Could not collect the files changed since revision", previousRevision+":", err.Error())
+		return nil
+	}
+	return changedFiles
+}
+
+// Returns the revision of the repository recorded by the previous build published to Artifactory, or an empty string if there is none.
+// The previous build is the last build started before the current one.
+func getPreviousBuildRevision(buildName, buildNumber, repositoryUrl string, artDetails *config.ArtifactoryDetails) (string, error) {
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
+	if err != nil {
+		return "", err
+	}
+	// The general details of the current build were saved when the command started.
+	generalDetails, err := utils.ReadBuildInfoGeneralDetails(buildName, buildNumber)
+	if err != nil {
+		return "", err
+	}
+	previousBuildNumber, err := utils.GetPreviousBuildNumber(buildName, buildNumber, generalDetails.Timestamp, artAuth)
+	if err != nil || previousBuildNumber == "" {
+		return "", err
+	}
+	previousBuildInfo, err := utils.GetPublishedBuildInfo(buildName, previousBuildNumber, artAuth)
+	if err != nil {
+		return "", err
+	}
+	return getRepositoryRevision(previousBuildInfo, repositoryUrl), nil
+}
+
+// Returns the revision of the repository recorded by the build-info, or an empty string if the build-info has no revision of the repository.
+// The revision of another repository, which the build may have also collected, is never returned.
+func getRepositoryRevision(buildInfo *utils.BuildInfo, repositoryUrl string) string {
+	for _, vcs := range buildInfo.VcsList {
+		if isSameRepository(vcs.Url, repositoryUrl) {
+			return vcs.Revision
+		}
+	}
+	if buildInfo.BuildInfo.Vcs != nil && isSameRepository(buildInfo.BuildInfo.Vcs.Url, repositoryUrl) {
+		return buildInfo.BuildInfo.Vcs.Revision
+	}
+	return ""
+}
+
+func isSameRepository(url, otherUrl string) bool {
+	return strings.TrimSuffix(url, ".git") == strings.TrimSuffix(otherUrl, ".git")
+}
+
+// Returns the detailed VCS collected by build-add-git. When the same revision of a repository was collected more than once, the last one is kept.
+func getVcsDetails(partials utils.Partials) []utils.Vcs {
+	var vcsList []utils.Vcs
+	for _, partial := range partials {
+		if partial.VcsDetails == nil {
+			continue
+		}
+		exists := false
+		for i := range vcsList {
+			if vcsList[i].Url == partial.VcsDetails.Url && vcsList[i].Revision == partial.VcsDetails.Revision {
+				vcsList[i], exists = *partial.VcsDetails, true
+				break
+			}
+		}
+		if !exists {
+			vcsList = append(vcsList, *partial.VcsDetails)
+		}
+	}
+	return vcsList
+}
+
+// The methods of the git manager used for reading the details of the repository.
+type gitDetailsReader interface {
+	GetUrl() string
+	GetRevision() string
+	GetBranch() string
+	ReadCommit() (*git.Commit, error)
+	IsDirty() (bool, error)
+	GetSubmodules() ([]git.Submodule, error)
+	GetChangedFiles(fromRevision string) ([]string, error)
+}
+
+type AddGitConfiguration struct {
+	BuildName   string
+	BuildNumber string
+	// The path of the directory containing the .git directory.
+	DotGitPath string
+	// If set, the files changed since the previous build published to Artifactory are collected.
+	ArtDetails *config.ArtifactoryDetails
+}
@@ -44,8 +44,8 @@ func checkFailureAndClean(t *testing.T, buildDir string, oldPath string) {
 	}
 }
 
-func getBuildInfoPartials(baseDir string, t *testing.T, buildName string, buildNumber string) buildinfo.Partials {
-	err := AddGit(buildName, buildNumber, baseDir)
+func getBuildInfoPartials(baseDir string, t *testing.T, buildName string, buildNumber string) utils.Partials {
+	err := AddGit(&AddGitConfiguration{BuildName: buildName, BuildNumber: buildNumber, DotGitPath: baseDir})
 	if err != nil {
 		t.Error("Cannot run build add git due to: " + err.Error())
 		return nil
@@ -67,7 +67,7 @@ func getBuildDir(t *testing.T) string {
 	return buildDir
 }
 
-func checkVCSUrl(partials buildinfo.Partials, t *testing.T) {
+func checkVCSUrl(partials utils.Partials, t *testing.T) {
 	for _, partial := range partials {
 		if partial.Vcs != nil {
 			url := partial.Vcs.Url
@@ -82,3 +82,23 @@ func checkVCSUrl(partials buildinfo.Partials, t *testing.T) {
 		}
 	}
 }
+
+func TestGetRepositoryRevision(t *testing.T) {
+	tests := []struct {
+		name      string
+		buildInfo *utils.BuildInfo
+		expected  string
+	}{
+		{"vcs list", &utils.BuildInfo{VcsList: []utils.Vcs{{Url: "https://github.com/jfrog/other.git", Revision: "1"}, {Url: "https://github.com/jfrog/repo.git", Revision: "2"}}}, "2"},
+		{"vcs", &utils.BuildInfo{BuildInfo: buildinfo.BuildInfo{Vcs: &buildinfo.Vcs{Url: "https://github.com/jfrog/repo", Revision: "3"}}}, "3"},
+		{"other repository", &utils.BuildInfo{BuildInfo: buildinfo.BuildInfo{Vcs: &buildinfo.Vcs{Url: "https://github.com/jfrog/other.git", Revision: "4"}}}, ""},
+		{"no vcs", &utils.BuildInfo{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if revision := getRepositoryRevision(test.buildInfo, "https://github.com/jfrog/repo.git"); revision != test.expected {
+				t.Error("Expected revision", test.expected+", got", revision)
+			}
+		})
+	}
+}
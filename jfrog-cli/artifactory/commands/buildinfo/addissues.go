@@ -0,0 +1,203 @@
+package buildinfo
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/git"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The keys of the issues config file.
+const (
+	issuesTrackerName       = "issues.trackerName"
+	issuesTrackerVersion    = "issues.trackerVersion"
+	issuesTrackerUrl        = "issues.trackerUrl"
+	issuesRegexps           = "issues.regexps"
+	issuesAggregate         = "issues.aggregate"
+	issuesAggregationStatus = "issues.aggregationStatus"
+	issuesServerId          = "issues.serverID"
+)
+
+// Matches Jira-style issue keys, such as ABC-123.
+const defaultIssueKeyRegexp = `\b[A-Z][A-Z0-9]+-[0-9]+\b`
+
+// The placeholder of the issue key in the URL template of the tracker.
+const issueKeyPlaceholder = "{key}"
+
+// The number of commits scanned when the previous build has no git revision.
+const issuesCommitsLimit = 100
+
+func AddIssues(configuration *AddIssuesConfiguration) error {
+	log.Info("Collecting build issues from git commit messages...")
+	buildName, buildNumber := configuration.BuildName, configuration.BuildNumber
+	issuesConfig, err := readIssuesConfig(configuration.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	artDetails := configuration.ArtDetails
+	if issuesConfig.serverId != "" {
+		artDetails, err = config.GetArtifactorySpecificConfig(issuesConfig.serverId)
+		if err != nil {
+			return err
+		}
+	}
+	err = utils.SaveBuildGeneralDetails(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	dotGitPath := configuration.DotGitPath
+	if dotGitPath == "" {
+		dotGitPath, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	gitManager := git.NewManager(dotGitPath)
+	err = gitManager.ReadConfig()
+	if err != nil {
+		return err
+	}
+	previousRevision, err := getPreviousBuildRevision(buildName, buildNumber, gitManager.GetUrl(), artDetails)
+	if err != nil {
+		return err
+	}
+	limit := 0
+	if previousRevision == "" {
+		log.Info("The previous build has no git revision, so the last", strconv.Itoa(issuesCommitsLimit), "commits are scanned.")
+		limit = issuesCommitsLimit
+	}
+	messages, err := gitManager.GetCommitMessages(previousRevision, limit)
+	if err != nil {
+		return err
+	}
+	issues := issuesConfig.issues
+	issues.AffectedIssues = issuesConfig.findAffectedIssues(messages)
+
+	populateFunc := func(partial *utils.Partial) {
+		partial.Issues = &issues
+	}
+	err = utils.SavePartialBuildInfo(buildName, buildNumber, populateFunc)
+	if err != nil {
+		return err
+	}
+	log.Info("Collected", strconv.Itoa(len(issues.AffectedIssues)), "issues for", buildName+"/"+buildNumber+".")
+	return nil
+}
+
+type issuesConfig struct {
+	// The tracker and aggregation settings of the issues.
+	issues utils.Issues
+	// The URL of an issue, in which the issue key placeholder is replaced with the issue key.
+	urlTemplate string
+	regexps     []*regexp.Regexp
+	serverId    string
+}
+
+func readIssuesConfig(configFilePath string) (*issuesConfig, error) {
+	vConfig, err := utils.ReadConfigFile(configFilePath, utils.YAML)
+	if err != nil {
+		return nil, err
+	}
+	trackerName := vConfig.GetString(issuesTrackerName)
+	if trackerName == "" {
+		return nil, errorutils.CheckError(errors.New("The issues tracker name is missing in " + configFilePath + "."))
+	}
+	issuesConfig := &issuesConfig{
+		issues: utils.Issues{
+			Tracker:                &utils.Tracker{Name: trackerName, Version: vConfig.GetString(issuesTrackerVersion)},
+			AggregateBuildIssues:   vConfig.GetBool(issuesAggregate),
+			AggregationBuildStatus: vConfig.GetString(issuesAggregationStatus),
+		},
+		urlTemplate: vConfig.GetString(issuesTrackerUrl),
+		serverId:    vConfig.GetString(issuesServerId),
+	}
+	patterns := vConfig.GetStringSlice(issuesRegexps)
+	if len(patterns) == 0 {
+		patterns = []string{defaultIssueKeyRegexp}
+	}
+	for _, pattern := range patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("Invalid issue key regexp " + pattern + ": " + err.Error()))
+		}
+		issuesConfig.regexps = append(issuesConfig.regexps, compiled)
+	}
+	return issuesConfig, nil
+}
+
+// Returns the issues the commit messages refer to. The summary of an issue is the first line of the newest commit message which refers to it.
+// If a regexp has a capturing group, the issue key is its first group. Otherwise, the issue key is the whole match.
+func (issuesConfig *issuesConfig) findAffectedIssues(messages []string) []utils.AffectedIssue {
+	var affectedIssues []utils.AffectedIssue
+	keys := make(map[string]bool)
+	for _, message := range messages {
+		summary := strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+		for _, compiled := range issuesConfig.regexps {
+			for _, match := range compiled.FindAllStringSubmatch(message, -1) {
+				key := match[0]
+				if len(match) > 1 {
+					key = match[1]
+				}
+				if key == "" || keys[key] {
+					continue
+				}
+				keys[key] = true
+				affectedIssues = append(affectedIssues, utils.AffectedIssue{Key: key, Url: issuesConfig.getIssueUrl(key), Summary: summary})
+			}
+		}
+	}
+	return affectedIssues
+}
+
+func (issuesConfig *issuesConfig) getIssueUrl(key string) string {
+	if issuesConfig.urlTemplate == "" {
+		return ""
+	}
+	if strings.Contains(issuesConfig.urlTemplate, issueKeyPlaceholder) {
+		return strings.Replace(issuesConfig.urlTemplate, issueKeyPlaceholder, key, -1)
+	}
+	return strings.TrimSuffix(issuesConfig.urlTemplate, "/") + "/" + key
+}
+
+// Returns the issues collected by build-add-issues. The tracker and aggregation settings collected last are used,
+// and the affected issues of all the partials are merged.
+func getIssues(partials utils.Partials) *utils.Issues {
+	var issues *utils.Issues
+	keys := make(map[string]bool)
+	for _, partial := range partials {
+		if partial.Issues == nil {
+			continue
+		}
+		var affectedIssues []utils.AffectedIssue
+		if issues != nil {
+			affectedIssues = issues.AffectedIssues
+		}
+		partialIssues := *partial.Issues
+		issues = &partialIssues
+		for _, affectedIssue := range partial.Issues.AffectedIssues {
+			if !keys[affectedIssue.Key] {
+				keys[affectedIssue.Key] = true
+				affectedIssues = append(affectedIssues, affectedIssue)
+			}
+		}
+		issues.AffectedIssues = affectedIssues
+	}
+	return issues
+}
+
+type AddIssuesConfiguration struct {
+	BuildName   string
+	BuildNumber string
+	// The path of the YAML file holding the issues tracker settings.
+	ConfigFilePath string
+	// The path of the directory containing the .git directory.
+	DotGitPath string
+	// The Artifactory server, in which the previous build is searched, unless the config file sets a server ID.
+	ArtDetails *config.ArtifactoryDetails
+}
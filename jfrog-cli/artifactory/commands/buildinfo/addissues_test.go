@@ -0,0 +1,55 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFindAffectedIssues(t *testing.T) {
+	issuesConfig, err := readIssuesConfig(filepath.Join("..", "..", "..", "testsdata", "buildspecs", "issues_config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issuesConfig.issues.Tracker.Name != "JIRA" || !issuesConfig.issues.AggregateBuildIssues || issuesConfig.issues.AggregationBuildStatus != "Released" {
+		t.Error("Unexpected issues settings:", issuesConfig.issues)
+	}
+	messages := []string{"ABC-2 Fix the upload\n\nAlso refers to DEF-10.", "ABC-1 Add the upload", "Refactor ABC-2", "No issue, abc-3 isn't a key"}
+	expected := []utils.AffectedIssue{
+		{Key: "ABC-2", Url: "https://jira.example.com/browse/ABC-2", Summary: "ABC-2 Fix the upload"},
+		{Key: "DEF-10", Url: "https://jira.example.com/browse/DEF-10", Summary: "ABC-2 Fix the upload"},
+		{Key: "ABC-1", Url: "https://jira.example.com/browse/ABC-1", Summary: "ABC-1 Add the upload"},
+	}
+	if affectedIssues := issuesConfig.findAffectedIssues(messages); !reflect.DeepEqual(affectedIssues, expected) {
+		t.Error("Unexpected affected issues:", affectedIssues)
+	}
+
+	// The first capturing group of the regexp is the issue key, and the key is appended to a tracker URL without a placeholder.
+	issuesConfig.regexps[0] = regexp.MustCompile(`\[(\w+)\]`)
+	issuesConfig.urlTemplate = "https://tracker.example.com/issues/"
+	expected = []utils.AffectedIssue{{Key: "42", Url: "https://tracker.example.com/issues/42", Summary: "[42] Fix the download"}}
+	if affectedIssues := issuesConfig.findAffectedIssues([]string{"[42] Fix the download"}); !reflect.DeepEqual(affectedIssues, expected) {
+		t.Error("Unexpected affected issues:", affectedIssues)
+	}
+}
+
+func TestGetIssues(t *testing.T) {
+	partials := utils.Partials{
+		{Issues: &utils.Issues{Tracker: &utils.Tracker{Name: "JIRA"}, AffectedIssues: []utils.AffectedIssue{{Key: "ABC-1"}, {Key: "ABC-2"}}}},
+		{},
+		{Issues: &utils.Issues{Tracker: &utils.Tracker{Name: "JIRA"}, AggregateBuildIssues: true, AffectedIssues: []utils.AffectedIssue{{Key: "ABC-2"}, {Key: "ABC-3"}}}},
+	}
+	issues := getIssues(partials)
+	expected := &utils.Issues{Tracker: &utils.Tracker{Name: "JIRA"}, AggregateBuildIssues: true, AffectedIssues: []utils.AffectedIssue{{Key: "ABC-1"}, {Key: "ABC-2"}, {Key: "ABC-3"}}}
+	if !reflect.DeepEqual(issues, expected) {
+		t.Error("Unexpected issues:", issues)
+	}
+	if len(partials[2].Issues.AffectedIssues) != 2 {
+		t.Error("The issues of the partials shouldn't change.")
+	}
+	if getIssues(utils.Partials{{}}) != nil {
+		t.Error("Expected no issues.")
+	}
+}
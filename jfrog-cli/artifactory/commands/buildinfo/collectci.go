@@ -0,0 +1,70 @@
+package buildinfo
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/ci"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func CollectCi(buildName, buildNumber string) error {
+	log.Info("Collecting CI details...")
+	ciDetails := ci.Detect()
+	if ciDetails == nil {
+		return errorutils.CheckError(errors.New("The build doesn't run on a supported CI provider."))
+	}
+	err := utils.SaveBuildGeneralDetails(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	populateFunc := func(partial *utils.Partial) {
+		partial.CiDetails = ciDetails
+	}
+	err = utils.SavePartialBuildInfo(buildName, buildNumber, populateFunc)
+	if err != nil {
+		return err
+	}
+	log.Info("Collected", ciDetails.Ci.Provider, "details for", buildName+"/"+buildNumber+".")
+	return nil
+}
+
+// Returns the CI details collected last, or nil if they weren't collected.
+func getCiDetails(partials utils.Partials) *utils.CiDetails {
+	var ciDetails *utils.CiDetails
+	for _, partial := range partials {
+		if partial.CiDetails != nil {
+			ciDetails = partial.CiDetails
+		}
+	}
+	return ciDetails
+}
+
+// The build URL configured explicitly and the VCS details collected by build-add-git take precedence over the CI details.
+func setCiDetails(buildInfo *utils.BuildInfo, ciDetails *utils.CiDetails) {
+	ciJob := ciDetails.Ci
+	buildInfo.Ci = &ciJob
+	buildInfo.Principal = ciDetails.Principal
+	if buildInfo.BuildUrl == "" {
+		buildInfo.BuildUrl = ciDetails.BuildUrl
+	}
+	if ciDetails.Vcs.Url == "" && ciDetails.Vcs.Revision == "" {
+		return
+	}
+	if !containsRevision(buildInfo.VcsList, ciDetails.Vcs.Revision) {
+		buildInfo.VcsList = append(buildInfo.VcsList, ciDetails.Vcs)
+	}
+	if buildInfo.Revision == "" && buildInfo.Url == "" {
+		buildInfo.Revision = ciDetails.Vcs.Revision
+		buildInfo.Url = ciDetails.Vcs.Url
+	}
+}
+
+func containsRevision(vcsList []utils.Vcs, revision string) bool {
+	for _, vcs := range vcsList {
+		if revision != "" && vcs.Revision == revision {
+			return true
+		}
+	}
+	return false
+}
@@ -2,9 +2,11 @@ package buildinfo
 
 import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/secrets"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -14,8 +16,10 @@ func CollectEnv(buildName, buildNumber string) error {
 	if err != nil {
 		return err
 	}
-	populateFunc := func(partial *buildinfo.Partial) {
-		partial.Env = getEnvVariables()
+	env := getEnvVariables()
+	logMaskedVariables(secrets.Mask(env))
+	populateFunc := func(partial *utils.Partial) {
+		partial.Env = env
 	}
 	err = utils.SavePartialBuildInfo(buildName, buildNumber, populateFunc)
 	if err != nil {
@@ -28,10 +32,21 @@ func CollectEnv(buildName, buildNumber string) error {
 func getEnvVariables() buildinfo.Env {
 	m := make(map[string]string)
 	for _, e := range os.Environ() {
-		pair := strings.Split(e, "=")
+		pair := strings.SplitN(e, "=", 2)
 		if len(pair[0]) != 0 {
 			m["buildInfo.env."+pair[0]] = pair[1]
 		}
 	}
 	return m
 }
+
+// Reports the variables whose values were masked, without their values.
+func logMaskedVariables(maskedVariables []secrets.MaskedVariable) {
+	if len(maskedVariables) == 0 {
+		return
+	}
+	log.Info("Masked the values of", strconv.Itoa(len(maskedVariables)), "environment variables which may contain secrets:")
+	for _, maskedVariable := range maskedVariables {
+		log.Info("  " + strings.TrimPrefix(maskedVariable.Key, "buildInfo.env.") + " (" + maskedVariable.Reason + ")")
+	}
+}
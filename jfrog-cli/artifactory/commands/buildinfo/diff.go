@@ -0,0 +1,249 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"sort"
+	"text/tabwriter"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+
+	DiffFormatTable = "table"
+	DiffFormatJson  = "json"
+)
+
+// The differences between two builds of the same build name.
+type BuildDiff struct {
+	BuildName    string         `json:"buildName"`
+	NumberA      string         `json:"numberA"`
+	NumberB      string         `json:"numberB"`
+	Modules      []ModuleDiff   `json:"modules,omitempty"`
+	Properties   []PropertyDiff `json:"properties,omitempty"`
+	VcsRevisionA string         `json:"vcsRevisionA,omitempty"`
+	VcsRevisionB string         `json:"vcsRevisionB,omitempty"`
+}
+
+type ModuleDiff struct {
+	Id           string     `json:"id"`
+	Change       string     `json:"change"`
+	Artifacts    []ItemDiff `json:"artifacts,omitempty"`
+	Dependencies []ItemDiff `json:"dependencies,omitempty"`
+}
+
+// An artifact or a dependency which was added, removed or changed.
+// Artifacts are identified by their paths, or by their names if their paths are unknown, and dependencies by their IDs.
+type ItemDiff struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	Sha1A  string `json:"sha1A,omitempty"`
+	Sha1B  string `json:"sha1B,omitempty"`
+}
+
+type PropertyDiff struct {
+	Key    string `json:"key"`
+	Change string `json:"change"`
+	ValueA string `json:"valueA,omitempty"`
+	ValueB string `json:"valueB,omitempty"`
+}
+
+// Compares two builds of the same build name. Each build is read from the build-info collected locally, if exists, or fetched from Artifactory.
+func Diff(buildName, numberA, numberB string, artDetails *config.ArtifactoryDetails) (*BuildDiff, error) {
+	buildInfoA, err := GetBuildInfo(buildName, numberA, artDetails)
+	if err != nil {
+		return nil, err
+	}
+	buildInfoB, err := GetBuildInfo(buildName, numberB, artDetails)
+	if err != nil {
+		return nil, err
+	}
+	return diffBuildInfo(buildName, numberA, numberB, buildInfoA, buildInfoB), nil
+}
+
+func diffBuildInfo(buildName, numberA, numberB string, buildInfoA, buildInfoB *utils.BuildInfo) *BuildDiff {
+	diff := &BuildDiff{BuildName: buildName, NumberA: numberA, NumberB: numberB}
+	modulesA, modulesB := modulesById(buildInfoA), modulesById(buildInfoB)
+	for _, id := range sortedModuleIds(modulesA, modulesB) {
+		moduleA, inA := modulesA[id]
+		moduleB, inB := modulesB[id]
+		moduleDiff := ModuleDiff{
+			Id:           id,
+			Change:       DiffChanged,
+			Artifacts:    diffItems(artifactsChecksums(moduleA), artifactsChecksums(moduleB)),
+			Dependencies: diffItems(dependenciesChecksums(moduleA), dependenciesChecksums(moduleB)),
+		}
+		switch {
+		case !inA:
+			moduleDiff.Change = DiffAdded
+		case !inB:
+			moduleDiff.Change = DiffRemoved
+		case len(moduleDiff.Artifacts) == 0 && len(moduleDiff.Dependencies) == 0:
+			continue
+		}
+		diff.Modules = append(diff.Modules, moduleDiff)
+	}
+	diff.Properties = diffProperties(buildInfoA.Properties, buildInfoB.Properties)
+	revisionA, revisionB := vcsRevision(buildInfoA), vcsRevision(buildInfoB)
+	if revisionA != revisionB {
+		diff.VcsRevisionA, diff.VcsRevisionB = revisionA, revisionB
+	}
+	return diff
+}
+
+// Compares items by their sha1 checksums. Items are mapped by their identifiers.
+func diffItems(itemsA, itemsB map[string]string) []ItemDiff {
+	var diffs []ItemDiff
+	for _, name := range sortedKeys(itemsA, itemsB) {
+		sha1A, inA := itemsA[name]
+		sha1B, inB := itemsB[name]
+		switch {
+		case !inA:
+			diffs = append(diffs, ItemDiff{Name: name, Change: DiffAdded, Sha1B: sha1B})
+		case !inB:
+			diffs = append(diffs, ItemDiff{Name: name, Change: DiffRemoved, Sha1A: sha1A})
+		case sha1A != sha1B:
+			diffs = append(diffs, ItemDiff{Name: name, Change: DiffChanged, Sha1A: sha1A, Sha1B: sha1B})
+		}
+	}
+	return diffs
+}
+
+func diffProperties(propertiesA, propertiesB buildinfo.Env) []PropertyDiff {
+	var diffs []PropertyDiff
+	for _, key := range sortedKeys(propertiesA, propertiesB) {
+		valueA, inA := propertiesA[key]
+		valueB, inB := propertiesB[key]
+		switch {
+		case !inA:
+			diffs = append(diffs, PropertyDiff{Key: key, Change: DiffAdded, ValueB: valueB})
+		case !inB:
+			diffs = append(diffs, PropertyDiff{Key: key, Change: DiffRemoved, ValueA: valueA})
+		case valueA != valueB:
+			diffs = append(diffs, PropertyDiff{Key: key, Change: DiffChanged, ValueA: valueA, ValueB: valueB})
+		}
+	}
+	return diffs
+}
+
+func modulesById(buildInfo *utils.BuildInfo) map[string]utils.Module {
+	modules := make(map[string]utils.Module)
+	for _, module := range buildInfo.Modules {
+		modules[module.Id] = module
+	}
+	return modules
+}
+
+func sortedModuleIds(modulesA, modulesB map[string]utils.Module) []string {
+	ids := make(map[string]string)
+	for id := range modulesA {
+		ids[id] = id
+	}
+	for id := range modulesB {
+		ids[id] = id
+	}
+	return sortedKeys(ids, nil)
+}
+
+// The artifacts are keyed by their paths, since artifacts with the same name may be deployed to different paths.
+func artifactsChecksums(module utils.Module) map[string]string {
+	checksums := make(map[string]string)
+	for _, artifact := range module.Artifacts {
+		checksums[artifact.GetFullPath()] = sha1OrEmpty(artifact.Checksum)
+	}
+	return checksums
+}
+
+func dependenciesChecksums(module utils.Module) map[string]string {
+	checksums := make(map[string]string)
+	for _, dependency := range module.Dependencies {
+		checksums[dependency.Id] = sha1OrEmpty(dependency.Checksum)
+	}
+	return checksums
+}
+
+func sha1OrEmpty(checksum *buildinfo.Checksum) string {
+	if checksum == nil {
+		return ""
+	}
+	return checksum.Sha1
+}
+
+func vcsRevision(buildInfo *utils.BuildInfo) string {
+	if buildInfo.Vcs == nil {
+		return ""
+	}
+	return buildInfo.Revision
+}
+
+// Returns the sorted union of the keys of both maps.
+func sortedKeys(mapA, mapB map[string]string) []string {
+	var keys []string
+	for key := range mapA {
+		keys = append(keys, key)
+	}
+	for key := range mapB {
+		if _, ok := mapA[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Prints the diff as a table or as JSON.
+func PrintDiff(diff *BuildDiff, format string) error {
+	if format == DiffFormatJson {
+		content, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+
+	if len(diff.Modules) == 0 && len(diff.Properties) == 0 && diff.VcsRevisionA == diff.VcsRevisionB {
+		log.Output("No differences found between builds " + diff.NumberA + " and " + diff.NumberB + " of " + diff.BuildName + ".")
+		return nil
+	}
+	var content bytes.Buffer
+	writer := tabwriter.NewWriter(&content, 0, 0, 2, ' ', 0)
+	if len(diff.Modules) > 0 {
+		fmt.Fprintf(writer, "MODULE\tTYPE\tNAME\tCHANGE\tSHA1 (%s)\tSHA1 (%s)\n", diff.NumberA, diff.NumberB)
+	}
+	for _, module := range diff.Modules {
+		if len(module.Artifacts) == 0 && len(module.Dependencies) == 0 {
+			fmt.Fprintf(writer, "%s\tmodule\t\t%s\t\t\n", module.Id, module.Change)
+		}
+		for _, artifact := range module.Artifacts {
+			fmt.Fprintf(writer, "%s\tartifact\t%s\t%s\t%s\t%s\n", module.Id, artifact.Name, artifact.Change, artifact.Sha1A, artifact.Sha1B)
+		}
+		for _, dependency := range module.Dependencies {
+			fmt.Fprintf(writer, "%s\tdependency\t%s\t%s\t%s\t%s\n", module.Id, dependency.Name, dependency.Change, dependency.Sha1A, dependency.Sha1B)
+		}
+	}
+	if len(diff.Properties) > 0 {
+		fmt.Fprintf(writer, "\n")
+		fmt.Fprintf(writer, "PROPERTY\tCHANGE\tVALUE (%s)\tVALUE (%s)\n", diff.NumberA, diff.NumberB)
+		for _, property := range diff.Properties {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", property.Key, property.Change, property.ValueA, property.ValueB)
+		}
+	}
+	if diff.VcsRevisionA != "" || diff.VcsRevisionB != "" {
+		fmt.Fprintf(writer, "\n")
+		fmt.Fprintf(writer, "VCS REVISION (%s)\tVCS REVISION (%s)\n%s\t%s\n", diff.NumberA, diff.NumberB, diff.VcsRevisionA, diff.VcsRevisionB)
+	}
+	if err := writer.Flush(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(content.String())
+	return nil
+}
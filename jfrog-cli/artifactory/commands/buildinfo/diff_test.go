@@ -0,0 +1,97 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"reflect"
+	"testing"
+)
+
+func TestDiffBuildInfo(t *testing.T) {
+	buildInfoA := utils.ConvertBuildInfo(&buildinfo.BuildInfo{
+		Modules: []buildinfo.Module{
+			{
+				Id: "app",
+				Artifacts: []buildinfo.Artifact{
+					{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: "1"}},
+					{Name: "app.pom", Checksum: &buildinfo.Checksum{Sha1: "2"}},
+				},
+				Dependencies: []buildinfo.Dependency{{Id: "lib:1.0", Checksum: &buildinfo.Checksum{Sha1: "3"}}},
+			},
+			{Id: "unchanged", Artifacts: []buildinfo.Artifact{{Name: "a.txt", Checksum: &buildinfo.Checksum{Sha1: "4"}}}},
+			{Id: "old", Artifacts: []buildinfo.Artifact{{Name: "old.txt", Checksum: &buildinfo.Checksum{Sha1: "5"}}}},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.KEPT": "a", "buildInfo.env.CHANGED": "a", "buildInfo.env.REMOVED": "a"},
+		Vcs:        &buildinfo.Vcs{Revision: "rev-a"},
+	})
+	buildInfoB := utils.ConvertBuildInfo(&buildinfo.BuildInfo{
+		Modules: []buildinfo.Module{
+			{
+				Id: "app",
+				Artifacts: []buildinfo.Artifact{
+					{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: "10"}},
+					{Name: "app.pom", Checksum: &buildinfo.Checksum{Sha1: "2"}},
+				},
+				Dependencies: []buildinfo.Dependency{{Id: "lib:2.0", Checksum: &buildinfo.Checksum{Sha1: "30"}}},
+			},
+			{Id: "unchanged", Artifacts: []buildinfo.Artifact{{Name: "a.txt", Checksum: &buildinfo.Checksum{Sha1: "4"}}}},
+			{Id: "new"},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.KEPT": "a", "buildInfo.env.CHANGED": "b", "buildInfo.env.ADDED": "b"},
+		Vcs:        &buildinfo.Vcs{Revision: "rev-b"},
+	})
+
+	diff := diffBuildInfo("build", "1", "2", buildInfoA, buildInfoB)
+	expectedModules := []ModuleDiff{
+		{
+			Id:        "app",
+			Change:    DiffChanged,
+			Artifacts: []ItemDiff{{Name: "app.jar", Change: DiffChanged, Sha1A: "1", Sha1B: "10"}},
+			Dependencies: []ItemDiff{
+				{Name: "lib:1.0", Change: DiffRemoved, Sha1A: "3"},
+				{Name: "lib:2.0", Change: DiffAdded, Sha1B: "30"},
+			},
+		},
+		{Id: "new", Change: DiffAdded},
+		{Id: "old", Change: DiffRemoved, Artifacts: []ItemDiff{{Name: "old.txt", Change: DiffRemoved, Sha1A: "5"}}},
+	}
+	if !reflect.DeepEqual(diff.Modules, expectedModules) {
+		t.Error("expected:", expectedModules, "got:", diff.Modules)
+	}
+	expectedProperties := []PropertyDiff{
+		{Key: "buildInfo.env.ADDED", Change: DiffAdded, ValueB: "b"},
+		{Key: "buildInfo.env.CHANGED", Change: DiffChanged, ValueA: "a", ValueB: "b"},
+		{Key: "buildInfo.env.REMOVED", Change: DiffRemoved, ValueA: "a"},
+	}
+	if !reflect.DeepEqual(diff.Properties, expectedProperties) {
+		t.Error("expected:", expectedProperties, "got:", diff.Properties)
+	}
+	if diff.VcsRevisionA != "rev-a" || diff.VcsRevisionB != "rev-b" {
+		t.Error("Unexpected VCS revisions:", diff.VcsRevisionA, diff.VcsRevisionB)
+	}
+
+	diff = diffBuildInfo("build", "1", "1", buildInfoA, buildInfoA)
+	if len(diff.Modules) != 0 || len(diff.Properties) != 0 || diff.VcsRevisionA != "" {
+		t.Error("Expected no differences between identical builds, got:", diff)
+	}
+}
+
+func TestDiffBuildInfoArtifactPaths(t *testing.T) {
+	// Artifacts with the same name in different paths are different artifacts.
+	newBuildInfo := func(sha1 string) *utils.BuildInfo {
+		buildInfo := utils.NewBuildInfo()
+		buildInfo.Modules = []utils.Module{{
+			Module: buildinfo.Module{Id: "app"},
+			Artifacts: []utils.Artifact{
+				{Artifact: buildinfo.Artifact{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: "1"}}, Path: "repo/linux/app.jar"},
+				{Artifact: buildinfo.Artifact{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: sha1}}, Path: "repo/windows/app.jar"},
+			},
+		}}
+		return buildInfo
+	}
+	diff := diffBuildInfo("build", "1", "2", newBuildInfo("2"), newBuildInfo("3"))
+	expectedModules := []ModuleDiff{{Id: "app", Change: DiffChanged, Artifacts: []ItemDiff{{Name: "repo/windows/app.jar", Change: DiffChanged, Sha1A: "2", Sha1B: "3"}}}}
+	if !reflect.DeepEqual(diff.Modules, expectedModules) {
+		t.Error("expected:", expectedModules, "got:", diff.Modules)
+	}
+}
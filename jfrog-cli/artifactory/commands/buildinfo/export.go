@@ -0,0 +1,42 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Writes the build-info collected locally to a file, so that it can be published later using build-import.
+// Unlike build-publish, the build-info collected locally is kept.
+func Export(buildName, buildNumber, targetPath string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails) error {
+	localBuildExists, err := utils.IsLocalBuildExists(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	if !localBuildExists {
+		return errorutils.CheckError(errors.New("No build-info was collected locally for build " + buildName + "/" + buildNumber + "."))
+	}
+	buildInfo, err := CreateBuildInfo(buildName, buildNumber, config, artDetails)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(buildInfo, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0777); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = ioutil.WriteFile(targetPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Exported build-info", buildName+"/"+buildNumber, "to", targetPath+".")
+	return nil
+}
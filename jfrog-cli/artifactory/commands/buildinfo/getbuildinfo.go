@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The patterns of the environment variables which are excluded from the build-info by default, since they may hold credentials.
+const DefaultEnvExclude = "*password*;*secret*;*key*;*token*"
+
+// Returns the build-info of the build.
+// If build-info data was collected locally for the build, the build-info is created from it, the same way it is created when published.
+// Otherwise, the build-info is fetched from Artifactory.
+func GetBuildInfo(buildName, buildNumber string, artDetails *config.ArtifactoryDetails) (*utils.BuildInfo, error) {
+	localBuildExists, err := utils.IsLocalBuildExists(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	if localBuildExists {
+		log.Debug("Reading the local build-info of", buildName+"/"+buildNumber)
+		configuration := &buildinfo.Configuration{EnvInclude: "*", EnvExclude: DefaultEnvExclude}
+		return CreateBuildInfo(buildName, buildNumber, configuration, artDetails)
+	}
+	if artDetails.Url == "" {
+		return nil, errorutils.CheckError(errors.New("Build " + buildName + "/" + buildNumber + " was not found locally, and no Artifactory URL was provided to fetch it from."))
+	}
+	log.Debug("Fetching the build-info of", buildName+"/"+buildNumber, "from Artifactory")
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetPublishedBuildInfo(buildName, buildNumber, artAuth)
+}
@@ -0,0 +1,90 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes a build-info file, created by build-export, to Artifactory.
+func Import(flags *BuildImportConfiguration) error {
+	buildInfo, err := ReadBuildInfoFile(flags.FilePath)
+	if err != nil {
+		return err
+	}
+	if err = validateImportedBuildInfo(buildInfo, flags.BuildName, flags.BuildNumber); err != nil {
+		return err
+	}
+	artAuth, err := flags.ArtDetails.CreateArtAuthConfig(lock.LockConfig)
+	if err != nil {
+		return err
+	}
+	if err = utils.PublishBuildInfo(buildInfo, artAuth, flags.DryRun); err != nil {
+		return err
+	}
+	log.Info("Imported build-info", buildInfo.Name+"/"+buildInfo.Number, "from", flags.FilePath+".")
+	return nil
+}
+
+// Reads a build-info file. Fields which are not part of the build-info schema are rejected.
+func ReadBuildInfoFile(filePath string) (*utils.BuildInfo, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	buildInfo := new(utils.BuildInfo)
+	if err = decoder.Decode(buildInfo); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a valid build-info file: %s", filePath, err.Error()))
+	}
+	return buildInfo, nil
+}
+
+// Validates the mandatory fields of the build-info.
+// If the build name or number are provided, they must match the build name and number of the build-info.
+func validateImportedBuildInfo(buildInfo *utils.BuildInfo, buildName, buildNumber string) error {
+	if buildInfo.Name == "" || buildInfo.Number == "" {
+		return errorutils.CheckError(errors.New("The build-info is missing the build name or number."))
+	}
+	if buildInfo.Started == "" {
+		return errorutils.CheckError(errors.New("The build-info is missing the build start time."))
+	}
+	if buildName != "" && buildName != buildInfo.Name {
+		return errorutils.CheckError(errors.New("The build-info belongs to build " + buildInfo.Name + ", not " + buildName + "."))
+	}
+	if buildNumber != "" && buildNumber != buildInfo.Number {
+		return errorutils.CheckError(errors.New("The build-info belongs to build number " + buildInfo.Number + ", not " + buildNumber + "."))
+	}
+	for _, module := range buildInfo.Modules {
+		if module.Id == "" {
+			return errorutils.CheckError(errors.New("The build-info contains a module with no ID."))
+		}
+		for _, artifact := range module.Artifacts {
+			if artifact.Name == "" {
+				return errorutils.CheckError(errors.New("The build-info module " + module.Id + " contains an artifact with no name."))
+			}
+		}
+		for _, dependency := range module.Dependencies {
+			if dependency.Id == "" {
+				return errorutils.CheckError(errors.New("The build-info module " + module.Id + " contains a dependency with no ID."))
+			}
+		}
+	}
+	return nil
+}
+
+type BuildImportConfiguration struct {
+	FilePath    string
+	BuildName   string
+	BuildNumber string
+	ArtDetails  *config.ArtifactoryDetails
+	DryRun      bool
+}
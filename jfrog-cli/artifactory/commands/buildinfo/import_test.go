@@ -0,0 +1,89 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportAndReadBuildInfoFile(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "build-export-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	previousBuildsDir := os.Getenv(config.BuildsDirEnv)
+	os.Setenv(config.BuildsDirEnv, tempDir)
+	defer os.Setenv(config.BuildsDirEnv, previousBuildsDir)
+
+	buildName, buildNumber := "export-test", "1"
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	err = utils.SavePartialBuildInfo(buildName, buildNumber, func(partial *utils.Partial) {
+		partial.Artifacts = []utils.Artifact{{
+			Artifact: buildinfo.Artifact{Name: "a.txt", Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}},
+			Type:     "txt",
+			Path:     "repo/a.txt",
+			Sha256:   "3",
+		}}
+		partial.ModuleId = "module"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exportPath := filepath.Join(tempDir, "export", "build-info.json")
+	configuration := &buildinfo.Configuration{EnvInclude: "*", EnvExclude: DefaultEnvExclude}
+	if err = Export(buildName, buildNumber, exportPath, configuration, &config.ArtifactoryDetails{User: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	buildInfo, err := ReadBuildInfoFile(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buildInfo.Name != buildName || buildInfo.Number != buildNumber || buildInfo.ArtifactoryPrincipal != "admin" {
+		t.Error("Unexpected build-info:", buildInfo)
+	}
+	if len(buildInfo.Modules) != 1 || len(buildInfo.Modules[0].Artifacts) != 1 {
+		t.Fatal("Unexpected build-info modules:", buildInfo.Modules)
+	}
+	if artifact := buildInfo.Modules[0].Artifacts[0]; artifact.Sha1 != "1" || artifact.Type != "txt" || artifact.Path != "repo/a.txt" || artifact.Sha256 != "3" {
+		t.Error("Unexpected build-info artifact:", artifact)
+	}
+	if err = validateImportedBuildInfo(buildInfo, buildName, buildNumber); err != nil {
+		t.Error(err)
+	}
+	if err = validateImportedBuildInfo(buildInfo, buildName, "2"); err == nil {
+		t.Error("Expected a build number mismatch error.")
+	}
+
+	if err = Export(buildName, "missing", exportPath, configuration, &config.ArtifactoryDetails{}); err == nil {
+		t.Error("Expected an error when exporting a build which wasn't collected locally.")
+	}
+}
+
+func TestReadInvalidBuildInfoFile(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "build-import-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "build-info.json")
+	if err = ioutil.WriteFile(filePath, []byte(`{"name": "a", "number": "1", "unknownField": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ReadBuildInfoFile(filePath); err == nil {
+		t.Error("Expected an error for a field which isn't part of the build-info schema.")
+	}
+
+	buildInfo := utils.ConvertBuildInfo(&buildinfo.BuildInfo{Name: "a", Number: "1", Started: "2018-01-01T00:00:00.000+0000", Modules: []buildinfo.Module{{Id: ""}}})
+	if err = validateImportedBuildInfo(buildInfo, "", ""); err == nil {
+		t.Error("Expected an error for a module with no ID.")
+	}
+}
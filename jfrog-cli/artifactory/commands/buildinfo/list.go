@@ -0,0 +1,62 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+)
+
+// Prints the builds collected locally, after removing the expired ones.
+func List() error {
+	if err := utils.RemoveExpiredBuilds(); err != nil {
+		return err
+	}
+	localBuilds, err := utils.ListLocalBuilds()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(localBuilds, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
+
+type BuildsStoreSettings struct {
+	Dir        string `json:"dir"`
+	ExpiryDays int    `json:"expiryDays"`
+}
+
+// Saves the builds store details which are set, and returns the settings in effect.
+func ConfigStore(dir string, expiryDays *int) (*BuildsStoreSettings, error) {
+	if dir != "" || expiryDays != nil {
+		details, err := config.ReadBuildsConf()
+		if err != nil {
+			return nil, err
+		}
+		if dir != "" {
+			if details.Dir, err = filepath.Abs(dir); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+		}
+		if expiryDays != nil {
+			details.ExpiryDays = expiryDays
+		}
+		if err = config.SaveBuildsConf(details); err != nil {
+			return nil, err
+		}
+	}
+	settings := new(BuildsStoreSettings)
+	var err error
+	if settings.Dir, err = config.GetBuildsDir(); err != nil {
+		return nil, err
+	}
+	if settings.ExpiryDays, err = config.GetBuildsExpiryDays(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
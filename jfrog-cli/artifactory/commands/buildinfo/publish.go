@@ -5,6 +5,8 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"path/filepath"
@@ -12,27 +14,22 @@ import (
 	"strings"
 )
 
-func Publish(buildName, buildNumber string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails) error {
-	servicesManager, err := utils.CreateServiceManager(artDetails, config.DryRun)
+// Publishes the build-info collected locally. If the detailed summary isn't nil, the published artifacts are added to it.
+func Publish(buildName, buildNumber string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails, detailedSummary *summary.Summary) error {
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return err
 	}
 
-	buildInfo, err := createBuildInfoFromPartials(buildName, buildNumber, config, artDetails)
+	buildInfo, err := CreateBuildInfo(buildName, buildNumber, config, artDetails)
 	if err != nil {
 		return err
 	}
-
-	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(buildName, buildNumber)
-	if err != nil {
-		return err
+	if detailedSummary != nil {
+		utils.AddBuildArtifactsToSummary(detailedSummary, buildInfo)
 	}
 
-	for _, v := range generatedBuildsInfo {
-		buildInfo.Append(v)
-	}
-
-	if err = servicesManager.PublishBuildInfo(buildInfo); err != nil {
+	if err = utils.PublishBuildInfo(buildInfo, artAuth, config.DryRun); err != nil {
 		return err
 	}
 
@@ -42,14 +39,32 @@ func Publish(buildName, buildNumber string, config *buildinfo.Configuration, art
 	return nil
 }
 
-func createBuildInfoFromPartials(buildName, buildNumber string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails) (*buildinfo.BuildInfo, error) {
+// Creates the build-info of the build from the partial build-info files and the build-info files generated by the build tools, collected locally.
+func CreateBuildInfo(buildName, buildNumber string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails) (*utils.BuildInfo, error) {
+	buildInfo, err := createBuildInfoFromPartials(buildName, buildNumber, config, artDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range generatedBuildsInfo {
+		buildInfo.Append(v)
+	}
+	return buildInfo, nil
+}
+
+func createBuildInfoFromPartials(buildName, buildNumber string, config *buildinfo.Configuration, artDetails *config.ArtifactoryDetails) (*utils.BuildInfo, error) {
 	partials, err := utils.ReadPartialBuildInfoFiles(buildName, buildNumber)
 	if err != nil {
 		return nil, err
 	}
 	sort.Sort(partials)
 
-	buildInfo := buildinfo.New()
+	buildInfo := utils.NewBuildInfo()
 	buildInfo.SetAgentName(cliutils.ClientAgent)
 	buildInfo.SetAgentVersion(cliutils.GetVersion())
 	buildInfo.SetBuildAgentVersion(cliutils.GetVersion())
@@ -59,7 +74,7 @@ func createBuildInfoFromPartials(buildName, buildNumber string, config *buildinf
 	if err != nil {
 		return nil, err
 	}
-	buildInfo.Started = buildGeneralDetails.Timestamp.Format("2006-01-02T15:04:05.000-0700")
+	buildInfo.Started = buildGeneralDetails.Timestamp.Format(utils.BuildStartedFormat)
 	modules, env, vcs, err := extractBuildInfoData(partials, createIncludeFilter(config.EnvInclude), createExcludeFilter(config.EnvExclude))
 	if err != nil {
 		return nil, err
@@ -73,6 +88,11 @@ func createBuildInfoFromPartials(buildName, buildNumber string, config *buildinf
 		buildInfo.Revision = vcs.Revision
 		buildInfo.Url = vcs.Url
 	}
+	buildInfo.VcsList = getVcsDetails(partials)
+	buildInfo.Issues = getIssues(partials)
+	if ciDetails := getCiDetails(partials); ciDetails != nil {
+		setCiDetails(buildInfo, ciDetails)
+	}
 	for _, module := range modules {
 		if module.Id == "" {
 			module.Id = buildName
@@ -82,7 +102,7 @@ func createBuildInfoFromPartials(buildName, buildNumber string, config *buildinf
 	return buildInfo, nil
 }
 
-func extractBuildInfoData(partials buildinfo.Partials, includeFilter, excludeFilter filterFunc) ([]buildinfo.Module, buildinfo.Env, buildinfo.Vcs, error) {
+func extractBuildInfoData(partials utils.Partials, includeFilter, excludeFilter filterFunc) ([]utils.Module, buildinfo.Env, buildinfo.Vcs, error) {
 	var vcs buildinfo.Vcs
 	env := make(map[string]string)
 	partialModules := make(map[string]partialModule)
@@ -115,8 +135,8 @@ func extractBuildInfoData(partials buildinfo.Partials, includeFilter, excludeFil
 	return partialModulesToModules(partialModules), env, vcs, nil
 }
 
-func partialModulesToModules(partialModules map[string]partialModule) []buildinfo.Module {
-	var modules []buildinfo.Module
+func partialModulesToModules(partialModules map[string]partialModule) []utils.Module {
+	var modules []utils.Module
 	for moduleId, singlePartialModule := range partialModules {
 		moduleArtifacts := artifactsMapToList(singlePartialModule.artifacts)
 		moduleDependencies := dependenciesMapToList(singlePartialModule.dependencies)
@@ -125,45 +145,45 @@ func partialModulesToModules(partialModules map[string]partialModule) []buildinf
 	return modules
 }
 
-func addDependencyToPartialModule(dependency buildinfo.Dependency, moduleId string, partialModules map[string]partialModule) {
+func addDependencyToPartialModule(dependency utils.Dependency, moduleId string, partialModules map[string]partialModule) {
 	// init map if needed
 	if partialModules[moduleId].dependencies == nil {
 		partialModules[moduleId] =
 			partialModule{artifacts: partialModules[moduleId].artifacts,
-				dependencies: make(map[string]buildinfo.Dependency)}
+				dependencies: make(map[string]utils.Dependency)}
 	}
-	key := fmt.Sprintf("%s-%s-%s-%s", dependency.Id, dependency.Sha1, dependency.Md5, dependency.Scopes)
+	key := fmt.Sprintf("%s-%s-%s-%s-%s", dependency.Id, dependency.Path, dependency.Sha1, dependency.Md5, dependency.Scopes)
 	partialModules[moduleId].dependencies[key] = dependency
 }
 
-func addArtifactToPartialModule(artifact buildinfo.Artifact, moduleId string, partialModules map[string]partialModule) {
+func addArtifactToPartialModule(artifact utils.Artifact, moduleId string, partialModules map[string]partialModule) {
 	// init map if needed
 	if partialModules[moduleId].artifacts == nil {
 		partialModules[moduleId] =
-			partialModule{artifacts: make(map[string]buildinfo.Artifact),
+			partialModule{artifacts: make(map[string]utils.Artifact),
 				dependencies: partialModules[moduleId].dependencies}
 	}
-	key := fmt.Sprintf("%s-%s-%s", artifact.Name, artifact.Sha1, artifact.Md5)
+	key := fmt.Sprintf("%s-%s-%s-%s", artifact.Name, artifact.Path, artifact.Sha1, artifact.Md5)
 	partialModules[moduleId].artifacts[key] = artifact
 }
 
-func artifactsMapToList(artifactsMap map[string]buildinfo.Artifact) []buildinfo.Artifact {
-	var artifacts []buildinfo.Artifact
+func artifactsMapToList(artifactsMap map[string]utils.Artifact) []utils.Artifact {
+	var artifacts []utils.Artifact
 	for _, artifact := range artifactsMap {
 		artifacts = append(artifacts, artifact)
 	}
 	return artifacts
 }
 
-func dependenciesMapToList(dependenciesMap map[string]buildinfo.Dependency) []buildinfo.Dependency {
-	var dependencies []buildinfo.Dependency
+func dependenciesMapToList(dependenciesMap map[string]utils.Dependency) []utils.Dependency {
+	var dependencies []utils.Dependency
 	for _, dependency := range dependenciesMap {
 		dependencies = append(dependencies, dependency)
 	}
 	return dependencies
 }
 
-func createModule(moduleId string, artifacts []buildinfo.Artifact, dependencies []buildinfo.Dependency) *buildinfo.Module {
+func createModule(moduleId string, artifacts []utils.Artifact, dependencies []utils.Dependency) *utils.Module {
 	module := createDefaultModule(moduleId)
 	if artifacts != nil && len(artifacts) > 0 {
 		module.Artifacts = append(module.Artifacts, artifacts...)
@@ -174,12 +194,14 @@ func createModule(moduleId string, artifacts []buildinfo.Artifact, dependencies
 	return module
 }
 
-func createDefaultModule(moduleId string) *buildinfo.Module {
-	return &buildinfo.Module{
-		Id:           moduleId,
-		Properties:   map[string][]string{},
-		Artifacts:    []buildinfo.Artifact{},
-		Dependencies: []buildinfo.Dependency{},
+func createDefaultModule(moduleId string) *utils.Module {
+	return &utils.Module{
+		Module: buildinfo.Module{
+			Id:         moduleId,
+			Properties: map[string][]string{},
+		},
+		Artifacts:    []utils.Artifact{},
+		Dependencies: []utils.Dependency{},
 	}
 }
 
@@ -230,6 +252,6 @@ func createExcludeFilter(pattern string) filterFunc {
 }
 
 type partialModule struct {
-	artifacts    map[string]buildinfo.Artifact
-	dependencies map[string]buildinfo.Dependency
+	artifacts    map[string]utils.Artifact
+	dependencies map[string]utils.Dependency
 }
@@ -1,6 +1,8 @@
 package buildinfo
 
 import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"reflect"
 	"testing"
 )
@@ -61,3 +63,55 @@ func TestExcludePasswordsPattern(t *testing.T) {
 		t.Error("expeted:", expected, "got:", filteredKeys)
 	}
 }
+
+func TestExtractBuildInfoDataModules(t *testing.T) {
+	partials := utils.Partials{
+		{Artifacts: utils.NewArtifacts([]buildinfo.Artifact{{Name: "a.zip", Checksum: &buildinfo.Checksum{Sha1: "1"}}}), Partial: buildinfo.Partial{ModuleId: "frontend"}},
+		{Dependencies: utils.NewDependencies([]buildinfo.Dependency{{Id: "b.zip", Checksum: &buildinfo.Checksum{Sha1: "2"}}}), Partial: buildinfo.Partial{ModuleId: "frontend"}},
+		{Artifacts: utils.NewArtifacts([]buildinfo.Artifact{{Name: "c.zip", Checksum: &buildinfo.Checksum{Sha1: "3"}}}), Partial: buildinfo.Partial{ModuleId: "backend"}},
+		{Artifacts: utils.NewArtifacts([]buildinfo.Artifact{{Name: "d.zip", Checksum: &buildinfo.Checksum{Sha1: "4"}}})},
+	}
+	modules, _, _, err := extractBuildInfoData(partials, createIncludeFilter("*"), createExcludeFilter(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	modulesById := make(map[string]utils.Module)
+	for _, module := range modules {
+		modulesById[module.Id] = module
+	}
+	if len(modulesById) != 3 {
+		t.Fatal("Expected 3 modules, got:", modules)
+	}
+	if frontend := modulesById["frontend"]; len(frontend.Artifacts) != 1 || len(frontend.Dependencies) != 1 {
+		t.Error("Unexpected frontend module:", frontend)
+	}
+	if backend := modulesById["backend"]; len(backend.Artifacts) != 1 || backend.Artifacts[0].Name != "c.zip" {
+		t.Error("Unexpected backend module:", backend)
+	}
+	// Partials without a module ID are added to the module which is later named after the build.
+	if defaultModule := modulesById[""]; len(defaultModule.Artifacts) != 1 || defaultModule.Artifacts[0].Name != "d.zip" {
+		t.Error("Unexpected default module:", defaultModule)
+	}
+}
+
+func TestSetCiDetails(t *testing.T) {
+	ciDetails := &utils.CiDetails{
+		Ci:       utils.Ci{Provider: "CircleCI", JobName: "build", JobId: "3"},
+		BuildUrl: "https://circleci.com/gh/org/app/3",
+		Vcs:      utils.Vcs{Url: "https://github.com/org/app", Revision: "abc", Branch: "main"},
+	}
+	buildInfo := utils.NewBuildInfo()
+	buildInfo.BuildUrl = "https://configured"
+	buildInfo.Revision = "def"
+	setCiDetails(buildInfo, ciDetails)
+	if buildInfo.Ci == nil || buildInfo.Ci.Provider != "CircleCI" {
+		t.Error("Unexpected CI details:", buildInfo.Ci)
+	}
+	// The configured build URL and the collected VCS details take precedence.
+	if buildInfo.BuildUrl != "https://configured" || buildInfo.Revision != "def" || buildInfo.Url != "" {
+		t.Error("Unexpected build URL or VCS details:", buildInfo.BuildUrl, buildInfo.Vcs)
+	}
+	if len(buildInfo.VcsList) != 1 || buildInfo.VcsList[0].Branch != "main" {
+		t.Error("Unexpected VCS list:", buildInfo.VcsList)
+	}
+}
@@ -0,0 +1,37 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/sbom"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+)
+
+// Creates an SBOM document of the build in the requested format.
+// The build-info is read from the build-info collected locally, if exists, or fetched from Artifactory.
+// If the output path is empty, the document is printed.
+func Sbom(buildName, buildNumber, format, outputPath string, artDetails *config.ArtifactoryDetails) error {
+	buildInfo, err := GetBuildInfo(buildName, buildNumber, artDetails)
+	if err != nil {
+		return err
+	}
+	document, err := sbom.Create(buildInfo, format)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if outputPath == "" {
+		log.Output(string(content))
+		return nil
+	}
+	if err = ioutil.WriteFile(outputPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Created the", format, "SBOM of build", buildName+"/"+buildNumber, "at", outputPath+".")
+	return nil
+}
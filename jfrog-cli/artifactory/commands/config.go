@@ -138,7 +138,7 @@ func getConfigurationFromUser(details, defaultDetails *config.ArtifactoryDetails
 			}
 		}
 	} else {
-		if details.ApiKey == "" && details.Password == "" {
+		if details.ApiKey == "" && details.Password == "" && details.AccessToken == "" {
 			ioutils.ReadCredentialsFromConsole(details, defaultDetails, allowUsingSavedPassword)
 		}
 	}
@@ -197,6 +197,12 @@ func printConfigs(configuration []*config.ArtifactoryDetails) {
 		if details.Password != "" {
 			log.Output("Password: ***")
 		}
+		if details.AccessToken != "" {
+			log.Output("Access token: ***")
+		}
+		if details.RefreshToken != "" {
+			log.Output("Refresh token: ***")
+		}
 		if details.SshKeyPath != "" {
 			log.Output("SSH key file path: " + details.SshKeyPath)
 		}
@@ -274,6 +280,54 @@ func ClearConfig(interactive bool) {
 	config.SaveArtifactoryConf(make([]*config.ArtifactoryDetails, 0))
 }
 
+// Returns a token of the configured server, which can be imported by another JFrog CLI.
+// If the passphrase isn't empty, the token is encrypted with it.
+func Export(serverId, passphrase string) (string, error) {
+	details, err := config.GetArtifactorySpecificConfig(serverId)
+	if err != nil {
+		return "", err
+	}
+	if details.IsEmpty() {
+		return "", errorutils.CheckError(errors.New("No Artifactory servers are configured."))
+	}
+	return config.CreateServerToken(details, passphrase)
+}
+
+// Adds the server of an exported token to the configuration.
+// A configured server with the same ID is replaced only if overwrite is true.
+// The imported server is the default server if it replaces the default server, or if no other server is configured.
+func Import(serverToken, passphrase string, overwrite bool) (*config.ArtifactoryDetails, error) {
+	details, err := config.ReadServerToken(serverToken, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	mutux.Lock()
+	lockFile, err := lock.CreateLock()
+	defer mutux.Unlock()
+	defer lockFile.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	configurations, err := config.GetAllArtifactoryConfigs()
+	if err != nil {
+		return nil, err
+	}
+	replaced, configurations := config.GetAndRemoveConfiguration(details.ServerId, configurations)
+	if replaced != nil {
+		if !overwrite {
+			return nil, errorutils.CheckError(errors.New(fmt.Sprintf("Server ID '%s' is already configured. Use the --overwrite option to replace it.", details.ServerId)))
+		}
+		details.IsDefault = replaced.IsDefault
+	}
+	if len(configurations) == 0 {
+		details.IsDefault = true
+	}
+	configurations = append(configurations, details)
+	return details, config.SaveArtifactoryConf(configurations)
+}
+
 func GetConfig(serverId string) (*config.ArtifactoryDetails, error) {
 	return config.GetArtifactorySpecificConfig(serverId)
 }
@@ -283,7 +337,8 @@ func EncryptPassword(details *config.ArtifactoryDetails) (*config.ArtifactoryDet
 		return details, nil
 	}
 	log.Info("Encrypting password...")
-	artAuth, err := details.CreateArtAuthConfig()
+	// The details have a password rather than an access token, so no refreshed token is written back to the configuration.
+	artAuth, err := details.CreateArtAuthConfig(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -296,9 +351,9 @@ func EncryptPassword(details *config.ArtifactoryDetails) (*config.ArtifactoryDet
 }
 
 func checkSingleAuthMethod(details *config.ArtifactoryDetails) error {
-	boolArr := []bool{details.User != "" && details.Password != "", details.ApiKey != "", fileutils.IsSshUrl(details.Url)}
+	boolArr := []bool{details.User != "" && details.Password != "", details.ApiKey != "", details.AccessToken != "", fileutils.IsSshUrl(details.Url)}
 	if cliutils.SumTrueValues(boolArr) > 1 {
-		return errorutils.CheckError(errors.New("Only one authentication method is allowd: Username/Password, API key or RSA tokens."))
+		return errorutils.CheckError(errors.New("Only one authentication method is allowd: Username/Password, API key, access token or RSA tokens."))
 	}
 	return nil
 }
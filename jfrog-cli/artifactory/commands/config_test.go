@@ -3,6 +3,8 @@ package commands
 import (
 	"encoding/json"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"io/ioutil"
+	"os"
 	"testing"
 )
 
@@ -28,6 +30,93 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestExportImport(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHomeDir := os.Getenv(config.JfrogHomeDirEnv)
+	os.Setenv(config.JfrogHomeDirEnv, homeDir)
+	defer os.Setenv(config.JfrogHomeDirEnv, previousHomeDir)
+
+	exported := &config.ArtifactoryDetails{Url: "http://localhost:8080/artifactory/", User: "admin", Password: "password", ServerId: "test", IsDefault: true}
+	if err = config.SaveArtifactoryConf([]*config.ArtifactoryDetails{exported}); err != nil {
+		t.Fatal(err)
+	}
+	plainToken, err := Export("test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedToken, err := Export("", "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encrypted, err := config.IsServerTokenEncrypted(encryptedToken); err != nil || !encrypted {
+		t.Error("Expected the token to be encrypted, got:", encrypted, err)
+	}
+	if encrypted, err := config.IsServerTokenEncrypted(plainToken); err != nil || encrypted {
+		t.Error("Expected the token not to be encrypted, got:", encrypted, err)
+	}
+
+	// Importing to another configuration, which includes a default server.
+	if err = config.SaveArtifactoryConf([]*config.ArtifactoryDetails{{Url: "http://other/", ServerId: "other", IsDefault: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Import(encryptedToken, "", false); err == nil {
+		t.Error("Expected an error for an encrypted token without a passphrase.")
+	}
+	if _, err = Import(encryptedToken, "wrong", false); err == nil {
+		t.Error("Expected an error for a wrong passphrase.")
+	}
+	imported, err := Import(encryptedToken, "passphrase", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.IsDefault {
+		t.Error("Expected the imported server not to replace the default server.")
+	}
+	if configStructToString(imported) != configStructToString(exported) {
+		t.Error("Unexpected imported server. Expected: " + configStructToString(exported) + " Got " + configStructToString(imported))
+	}
+
+	// A server with the same ID is replaced only if overwrite is set.
+	if _, err = Import(plainToken, "", false); err == nil {
+		t.Error("Expected an error for an existing server ID.")
+	}
+	if _, err = Import(plainToken, "", true); err != nil {
+		t.Error(err)
+	}
+	configurations, err := config.GetAllArtifactoryConfigs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configurations) != 2 || configurations[0].ServerId != "other" || !configurations[0].IsDefault || configurations[1].ServerId != "test" {
+		t.Error("Unexpected configuration after the import:", configurations)
+	}
+}
+
+func TestExportSshKeyPath(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHomeDir := os.Getenv(config.JfrogHomeDirEnv)
+	os.Setenv(config.JfrogHomeDirEnv, homeDir)
+	defer os.Setenv(config.JfrogHomeDirEnv, previousHomeDir)
+
+	// The SSH key file is local to this machine, so the server isn't exported.
+	sshServer := &config.ArtifactoryDetails{Url: "ssh://localhost:1339/", SshKeyPath: "~/.ssh/id_rsa", ServerId: "ssh", IsDefault: true}
+	if err = config.SaveArtifactoryConf([]*config.ArtifactoryDetails{sshServer}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Export("ssh", ""); err == nil {
+		t.Error("Expected an error for exporting a server with an SSH key file.")
+	}
+}
+
 func configStructToString(artConfig *config.ArtifactoryDetails) string {
 	artConfig.IsDefault = false
 	marshaledStruct, _ := json.Marshal(*artConfig)
@@ -4,11 +4,13 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/docker"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"strings"
 )
 
-// Push docker image and create build info if needed
-func PushDockerImage(imageTag, targetRepo, buildName, buildNumber string, artDetails *config.ArtifactoryDetails, threads int) error {
+// Push docker image and create build info if needed.
+// If the detailed summary isn't nil, the image and its layers are added to it.
+func PushDockerImage(imageTag, targetRepo, buildName, buildNumber string, artDetails *config.ArtifactoryDetails, threads int, detailedSummary *summary.Summary) error {
 	// Perform login
 	loginConfig := &docker.DockerLoginConfig{ArtifactoryDetails: artDetails}
 	err := docker.DockerLogin(imageTag, loginConfig)
@@ -25,6 +27,9 @@ func PushDockerImage(imageTag, targetRepo, buildName, buildNumber string, artDet
 	if err != nil {
 		return err
 	}
+	if detailedSummary != nil {
+		detailedSummary.AddFile(summary.File{Source: imageTag, Target: targetRepo})
+	}
 
 	// Return if no build name and number was provided
 	if buildName == "" || buildNumber == "" {
@@ -45,5 +50,8 @@ func PushDockerImage(imageTag, targetRepo, buildName, buildNumber string, artDet
 	if err != nil {
 		return err
 	}
+	if detailedSummary != nil {
+		utils.AddBuildArtifactsToSummary(detailedSummary, utils.ConvertBuildInfo(buildInfo))
+	}
 	return utils.SaveBuildInfo(buildName, buildNumber, buildInfo)
 }
@@ -0,0 +1,23 @@
+package generic
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func CacheClean() error {
+	downloadCache, err := cache.New()
+	if err != nil {
+		return err
+	}
+	log.Info("Cleaning the local download cache at", downloadCache.GetDir())
+	return downloadCache.Clean()
+}
+
+func CacheStats() (*cache.Stats, error) {
+	downloadCache, err := cache.New()
+	if err != nil {
+		return nil, err
+	}
+	return downloadCache.Stats()
+}
@@ -1,11 +1,14 @@
 package generic
 
 import (
-	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
 )
 
 // Copies the artifacts using the specified move pattern.
@@ -25,9 +28,21 @@ func Copy(copySpec *spec.SpecFiles, flags *CopyConfiguration) (successCount, fai
 			log.Error(err)
 			continue
 		}
+		if flags.Plan != nil {
+			planned, err := addMoveCopyItemsToPlan(flags.Plan, servicesManager, copySpec.Get(i), flat)
+			successCount += planned
+			if err != nil {
+				log.Error(err)
+			}
+			continue
+		}
+		startTime := time.Now()
 		partialSuccess, partialFailed, err := servicesManager.Copy(&services.MoveCopyParamsImpl{ArtifactoryCommonParams: params, Flat: flat})
 		successCount += partialSuccess
 		failCount += partialFailed
+		if flags.DetailedSummary != nil {
+			addFailedGroupToSummary(flags.DetailedSummary, params.Pattern, params.Target, partialFailed, err, startTime)
+		}
 		if err != nil {
 			log.Error(err)
 			continue
@@ -36,7 +51,15 @@ func Copy(copySpec *spec.SpecFiles, flags *CopyConfiguration) (successCount, fai
 	return
 }
 
+// Copies the items of a plan created by the copy command to their planned targets.
+func CopyPlannedItems(plan *Plan, plannedItems []clientutils.ResultItem, flags *CopyConfiguration) (successCount, failCount int, err error) {
+	return moveCopyPlannedItems(plan, plannedItems, services.COPY, flags.ArtDetails, flags.DryRun, flags.DetailedSummary)
+}
+
 type CopyConfiguration struct {
-	DryRun                bool
-	ArtDetails            *config.ArtifactoryDetails
-}
\ No newline at end of file
+	DryRun          bool
+	ArtDetails      *config.ArtifactoryDetails
+	DetailedSummary *summary.Summary
+	// If set, the files are added to the plan rather than being copied.
+	Plan *Plan
+}
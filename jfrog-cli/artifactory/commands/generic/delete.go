@@ -1,11 +1,14 @@
 package generic
 
 import (
+	"errors"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 )
 
 func Delete(deleteSpec *spec.SpecFiles, flags *DeleteConfiguration) (successCount, failCount int, err error) {
@@ -35,6 +38,17 @@ func DeleteFiles(resultItems []clientutils.ResultItem, flags *DeleteConfiguratio
 	if err != nil {
 		return 0, 0, err
 	}
+	if flags.DetailedSummary != nil {
+		// The items are deleted one by one, so that the result of each item is added to the summary.
+		successCount, failedCount = runOnItems(flags.DetailedSummary, toTargetItems(resultItems), 1, func(item targetItem) error {
+			deleted, err := servicesManager.DeleteFiles([]services.DeleteItem{item.ResultItem})
+			if err == nil && deleted == 0 && !flags.DryRun {
+				err = errorutils.CheckError(errors.New("Failed deleting " + item.GetItemRelativePath() + ". Please review the logs."))
+			}
+			return err
+		})
+		return successCount, failedCount, nil
+	}
 	deleteItems := utils.ConvertResultItemArrayToDeleteItemArray(resultItems)
 	deletedCount, err := servicesManager.DeleteFiles(deleteItems)
 	return deletedCount, len(deleteItems) - deletedCount, err
@@ -61,6 +75,7 @@ func GetPathsToDelete(deleteSpec *spec.SpecFiles, flags *DeleteConfiguration) ([
 }
 
 type DeleteConfiguration struct {
-	ArtDetails *config.ArtifactoryDetails
-	DryRun     bool
+	ArtDetails      *config.ArtifactoryDetails
+	DryRun          bool
+	DetailedSummary *summary.Summary
 }
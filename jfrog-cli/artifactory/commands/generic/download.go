@@ -4,7 +4,10 @@ import (
 	"errors"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cache"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -12,6 +15,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"strconv"
+	"time"
 )
 
 func Download(downloadSpec *spec.SpecFiles, configuration *DownloadConfiguration) (successCount, failCount int, err error) {
@@ -32,6 +36,13 @@ func Download(downloadSpec *spec.SpecFiles, configuration *DownloadConfiguration
 		}
 		defer fileutils.RemoveTempDir()
 	}
+	var downloadCache *cache.Cache
+	if configuration.Cache && !configuration.DryRun {
+		downloadCache, err = cache.New()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
 	var filesInfo []clientutils.FileInfo
 	var totalExpected int
 	var errorOccurred = false
@@ -55,16 +66,48 @@ func Download(downloadSpec *spec.SpecFiles, configuration *DownloadConfiguration
 			log.Error(err)
 			continue
 		}
+		if configuration.Plan != nil {
+			planned, err := addDownloadedFilesToPlan(configuration.Plan, servicesManager, params, flat, explode)
+			totalExpected += planned
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+			}
+			continue
+		}
+		// Exploded archives are removed after the download, so they cannot be cached.
+		useCache := downloadCache != nil && !explode
+		if useCache {
+			if err = getFilesFromCache(servicesManager, downloadCache, downloadSpec.Get(i), flat); err != nil {
+				log.Warn("Failed to get files from the local cache:", err.Error())
+			}
+		}
 
+		startTime := time.Now()
 		currentBuildDependencies, expected, err := servicesManager.DownloadFiles(&services.DownloadParamsImpl{ArtifactoryCommonParams: params, ValidateSymlink: configuration.ValidateSymlink, Symlink: configuration.Symlink, Flat: flat, Explode: explode, Retries: configuration.Retries})
 		totalExpected += expected
 		filesInfo = append(filesInfo, currentBuildDependencies...)
+		// The download doesn't return the files in dry run.
+		if configuration.DetailedSummary != nil && !configuration.DryRun {
+			addDownloadedFilesToSummary(configuration.DetailedSummary, currentBuildDependencies, startTime)
+			addFailedGroupToSummary(configuration.DetailedSummary, params.Pattern, params.Target, expected-len(currentBuildDependencies), err, startTime)
+		}
+		if useCache {
+			if cacheErr := putFilesInCache(downloadCache, currentBuildDependencies); cacheErr != nil {
+				log.Warn("Failed to add files to the local cache:", cacheErr.Error())
+			}
+		}
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
 			continue
 		}
 	}
+	if downloadCache != nil {
+		if cacheErr := downloadCache.Evict(); cacheErr != nil {
+			log.Warn("Failed to evict files from the local cache:", cacheErr.Error())
+		}
+	}
 	if errorOccurred {
 		return len(filesInfo), totalExpected - len(filesInfo), errors.New("Download finished with errors. Please review the logs")
 	}
@@ -74,8 +117,9 @@ func Download(downloadSpec *spec.SpecFiles, configuration *DownloadConfiguration
 	log.Debug("Downloaded", strconv.Itoa(len(filesInfo)), "artifacts.")
 	buildDependencies := convertFileInfoToBuildDependencies(filesInfo)
 	if isCollectBuildInfo {
-		populateFunc := func(partial *buildinfo.Partial) {
+		populateFunc := func(partial *utils.Partial) {
 			partial.Dependencies = buildDependencies
+			partial.ModuleId = configuration.Module
 		}
 		err = utils.SavePartialBuildInfo(configuration.BuildName, configuration.BuildNumber, populateFunc)
 	}
@@ -83,10 +127,10 @@ func Download(downloadSpec *spec.SpecFiles, configuration *DownloadConfiguration
 	return len(filesInfo), totalExpected - len(filesInfo), err
 }
 
-func convertFileInfoToBuildDependencies(filesInfo []clientutils.FileInfo) []buildinfo.Dependency {
-	buildDependecies := make([]buildinfo.Dependency, len(filesInfo))
+func convertFileInfoToBuildDependencies(filesInfo []clientutils.FileInfo) []utils.Dependency {
+	buildDependecies := make([]utils.Dependency, len(filesInfo))
 	for i, fileInfo := range filesInfo {
-		dependency := buildinfo.Dependency{Checksum: &buildinfo.Checksum{}}
+		dependency := utils.Dependency{Dependency: buildinfo.Dependency{Checksum: &buildinfo.Checksum{}}}
 		dependency.Md5 = fileInfo.Md5
 		dependency.Sha1 = fileInfo.Sha1
 		filename, _ := fileutils.GetFileAndDirFromPath(fileInfo.ArtifactoryPath)
@@ -102,11 +146,16 @@ type DownloadConfiguration struct {
 	MinSplitSize    int64
 	BuildName       string
 	BuildNumber     string
+	Module          string
 	DryRun          bool
 	Symlink         bool
 	ValidateSymlink bool
 	ArtDetails      *config.ArtifactoryDetails
 	Retries         int
+	Cache           bool
+	DetailedSummary *summary.Summary
+	// If set, the files are added to the plan rather than being downloaded.
+	Plan *Plan
 }
 
 func createDownloadServiceManager(artDetails *config.ArtifactoryDetails, flags *DownloadConfiguration) (*artifactory.ArtifactoryServicesManager, error) {
@@ -114,7 +163,7 @@ func createDownloadServiceManager(artDetails *config.ArtifactoryDetails, flags *
 	if err != nil {
 		return nil, err
 	}
-	artAuth, err := artDetails.CreateArtAuthConfig()
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return nil, err
 	}
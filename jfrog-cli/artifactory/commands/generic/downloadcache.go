@@ -0,0 +1,89 @@
+package generic
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+)
+
+const symlinkDestProp = "symlink.dest"
+
+// Places the files of the spec which are already stored in the local cache in their download target paths.
+// Since the checksums of these files match the checksums reported by Artifactory, the download skips them.
+// The download searches the files itself, so the files are searched here only if the cache isn't empty.
+func getFilesFromCache(servicesManager *artifactory.ArtifactoryServicesManager, downloadCache *cache.Cache, file *spec.File, flat bool) error {
+	empty, err := downloadCache.IsEmpty()
+	if err != nil || empty {
+		return err
+	}
+	// The search params are created separately from the download params, since the search modifies them.
+	params, err := file.ToArtifatoryDownloadParams()
+	if err != nil {
+		return err
+	}
+	resultItems, err := servicesManager.Search(clientutils.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return err
+	}
+	var cachedCount int
+	for _, item := range resultItems {
+		if item.Type == "folder" || isSymlinkItem(item) {
+			continue
+		}
+		localPath, err := getDownloadLocalPath(item, params.Pattern, params.Target, flat)
+		if err != nil {
+			return err
+		}
+		found, err := downloadCache.Get(item.Actual_Sha1, item.Size, localPath)
+		if err != nil {
+			return err
+		}
+		if found {
+			log.Debug("Found", item.GetItemRelativePath(), "in the local cache.")
+			cachedCount++
+		}
+	}
+	if cachedCount > 0 {
+		log.Info("Found", cachedCount, "artifacts in the local cache.")
+	}
+	return nil
+}
+
+// Adds the downloaded files to the local cache. The least recently used files are evicted once the download is done.
+func putFilesInCache(downloadCache *cache.Cache, filesInfo []clientutils.FileInfo) error {
+	for _, fileInfo := range filesInfo {
+		// Symlinks hold the checksum of the symlink artifact rather than of the local file they point to.
+		if fileInfo.FileHashes == nil || fileInfo.Sha1 == "" || fileutils.IsPathSymlink(fileInfo.LocalPath) {
+			continue
+		}
+		if err := downloadCache.Put(fileInfo.Sha1, fileInfo.LocalPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Calculates the local path of a downloaded item, the same way the download does.
+func getDownloadLocalPath(item clientutils.ResultItem, pattern, target string, flat bool) (string, error) {
+	targetPath, err := utils.BuildTargetPath(pattern, item.GetItemRelativePath(), target, true)
+	if err != nil {
+		return "", err
+	}
+	localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, targetPath, flat)
+	return filepath.Join(localPath, localFileName), nil
+}
+
+// Symlinks are created by the download rather than downloaded, so they are not cached.
+func isSymlinkItem(item clientutils.ResultItem) bool {
+	for _, prop := range item.Properties {
+		if prop.Key == symlinkDestProp {
+			return true
+		}
+	}
+	return false
+}
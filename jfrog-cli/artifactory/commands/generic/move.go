@@ -1,11 +1,14 @@
 package generic
 
 import (
-	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
 )
 
 // Moves the artifacts using the specified move pattern.
@@ -25,9 +28,21 @@ func Move(moveSpec *spec.SpecFiles, flags *MoveConfiguration) (successCount, fai
 			log.Error(err)
 			continue
 		}
+		if flags.Plan != nil {
+			planned, err := addMoveCopyItemsToPlan(flags.Plan, servicesManager, moveSpec.Get(i), flat)
+			successCount += planned
+			if err != nil {
+				log.Error(err)
+			}
+			continue
+		}
+		startTime := time.Now()
 		partialSuccess, partialFailed, err := servicesManager.Move(&services.MoveCopyParamsImpl{ArtifactoryCommonParams: params, Flat: flat})
 		successCount += partialSuccess
 		failCount += partialFailed
+		if flags.DetailedSummary != nil {
+			addFailedGroupToSummary(flags.DetailedSummary, params.Pattern, params.Target, partialFailed, err, startTime)
+		}
 		if err != nil {
 			log.Error(err)
 			continue
@@ -36,7 +51,15 @@ func Move(moveSpec *spec.SpecFiles, flags *MoveConfiguration) (successCount, fai
 	return
 }
 
+// Moves the items of a plan created by the move command to their planned targets.
+func MovePlannedItems(plan *Plan, plannedItems []clientutils.ResultItem, flags *MoveConfiguration) (successCount, failCount int, err error) {
+	return moveCopyPlannedItems(plan, plannedItems, services.MOVE, flags.ArtDetails, flags.DryRun, flags.DetailedSummary)
+}
+
 type MoveConfiguration struct {
-	DryRun                bool
-	ArtDetails            *config.ArtifactoryDetails
-}
\ No newline at end of file
+	DryRun          bool
+	ArtDetails      *config.ArtifactoryDetails
+	DetailedSummary *summary.Summary
+	// If set, the files are added to the plan rather than being moved.
+	Plan *Plan
+}
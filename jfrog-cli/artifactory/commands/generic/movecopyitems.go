@@ -0,0 +1,83 @@
+package generic
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/auth/cert"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/errors/httperrors"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Moves or copies each of the planned items to its planned target path, and adds the result of each item to the detailed summary.
+// Used only for executing plans, which handle the exact planned items rather than the items matched by the patterns of the client's move and copy.
+func moveCopyItems(servicesManager *artifactory.ArtifactoryServicesManager, moveType services.MoveType, items []targetItem, detailedSummary *summary.Summary) (successCount, failCount int, err error) {
+	securityDir, err := utils.GetJfrogSecurityDir()
+	if err != nil {
+		return 0, 0, err
+	}
+	transport, err := cert.GetTransportWithLoadedCert(securityDir)
+	if err != nil {
+		return 0, 0, err
+	}
+	client := httpclient.NewHttpClient(&http.Client{Transport: transport})
+	artDetails := servicesManager.GetConfig().GetArtDetails()
+	dryRun := servicesManager.GetConfig().IsDryRun()
+	successCount, failCount = runOnItems(detailedSummary, items, 1, func(item targetItem) error {
+		return moveCopyItem(client, artDetails.GetUrl(), artDetails.CreateHttpClientDetails(), moveType, item, dryRun)
+	})
+	return
+}
+
+func moveCopyItem(client *httpclient.HttpClient, artifactoryUrl string, httpClientsDetails httputils.HttpClientDetails, moveType services.MoveType, item targetItem, dryRun bool) error {
+	if item.target == "" {
+		return errorutils.CheckError(errors.New("Failed calculating the target path of " + item.GetItemRelativePath() + "."))
+	}
+	message := "Moving artifact: " + item.GetItemRelativePath() + " to: " + item.target
+	if moveType == services.COPY {
+		message = "Copying artifact: " + item.GetItemRelativePath() + " to: " + item.target
+	}
+	params := map[string]string{"to": item.target}
+	if dryRun {
+		log.Info("[Dry run]", message)
+		params["dry"] = "1"
+	} else {
+		log.Info(message)
+		// A folder is moved into its target folder, which is created first.
+		if item.Type == "folder" && strings.HasSuffix(item.target, "/") {
+			if err := createFolder(client, artifactoryUrl, httpClientsDetails, item.target); err != nil {
+				return err
+			}
+		}
+	}
+	requestUrl, err := clientutils.BuildArtifactoryUrl(artifactoryUrl, path.Join("api", string(moveType), item.GetItemRelativePath()), params)
+	if err != nil {
+		return err
+	}
+	resp, body, err := client.SendPost(requestUrl, nil, httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	return httperrors.CheckResponseStatus(resp, body, http.StatusOK)
+}
+
+func createFolder(client *httpclient.HttpClient, artifactoryUrl string, httpClientsDetails httputils.HttpClientDetails, folderPath string) error {
+	requestUrl, err := clientutils.BuildArtifactoryUrl(artifactoryUrl, folderPath, map[string]string{})
+	if err != nil {
+		return err
+	}
+	resp, body, err := client.SendPut(requestUrl, nil, httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	return httperrors.CheckResponseStatus(resp, body, http.StatusCreated)
+}
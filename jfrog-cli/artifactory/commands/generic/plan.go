@@ -0,0 +1,318 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	rtutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const planVersion = 1
+
+// The operations resolved from the spec of a command, which are written by the --plan-out option without being executed.
+// Once reviewed, the plan is executed by the --plan-in option, which handles exactly the planned paths.
+type Plan struct {
+	Version int    `json:"version"`
+	Command string `json:"command"`
+	// The Artifactory server the plan was created for.
+	Url string `json:"url"`
+	// The properties set or deleted by the set-props and delete-props commands.
+	Props      string          `json:"props,omitempty"`
+	Operations []PlanOperation `json:"operations"`
+}
+
+// A single file or folder handled by the command. The source and target are Artifactory paths, or local paths for the uploaded and downloaded files.
+type PlanOperation struct {
+	Source string `json:"source"`
+	Target string `json:"target,omitempty"`
+	// Empty for files, and "folder" for folders.
+	Type string `json:"type,omitempty"`
+	Size int64  `json:"size,omitempty"`
+	Sha1 string `json:"sha1,omitempty"`
+	Md5  string `json:"md5,omitempty"`
+	// The properties attached to the uploaded file.
+	Props string `json:"props,omitempty"`
+	// Whether the uploaded or downloaded archive is extracted.
+	Explode bool `json:"explode,omitempty"`
+}
+
+func NewPlan(command, artifactoryUrl string) *Plan {
+	return &Plan{Version: planVersion, Command: command, Url: artifactoryUrl, Operations: []PlanOperation{}}
+}
+
+func (plan *Plan) AddOperation(operation PlanOperation) {
+	plan.Operations = append(plan.Operations, operation)
+}
+
+// Adds an Artifactory item, which is the source of the operation. The target is optional.
+func (plan *Plan) AddItem(item clientutils.ResultItem, target string) {
+	operation := PlanOperation{Source: item.GetItemRelativePath(), Target: target, Size: item.Size, Sha1: item.Actual_Sha1, Md5: item.Actual_Md5}
+	if item.Type == "folder" {
+		operation.Type = item.Type
+	}
+	plan.AddOperation(operation)
+}
+
+func (plan *Plan) Save(planPath string) error {
+	content, err := json.MarshalIndent(plan, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(planPath, content, 0644))
+}
+
+// Reads a plan, which must have been created by the same command for the same Artifactory server.
+func ReadPlan(planPath, command, artifactoryUrl string) (*Plan, error) {
+	content, err := fileutils.ReadFile(planPath)
+	if err != nil {
+		return nil, err
+	}
+	plan := new(Plan)
+	if err = json.Unmarshal(content, plan); err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed reading the plan " + planPath + ": " + err.Error()))
+	}
+	switch {
+	case plan.Version != planVersion:
+		err = errors.New("The plan " + planPath + " was created by an incompatible version of JFrog CLI.")
+	case plan.Command != command:
+		err = errors.New("The plan " + planPath + " was created by the " + plan.Command + " command, and cannot be executed by the " + command + " command.")
+	case strings.TrimSuffix(plan.Url, "/") != strings.TrimSuffix(artifactoryUrl, "/"):
+		err = errors.New("The plan " + planPath + " was created for " + plan.Url + ", and cannot be executed on " + artifactoryUrl + ".")
+	}
+	return plan, errorutils.CheckError(err)
+}
+
+// Returns a spec with a file group for each planned file, which matches exactly its source and sends it to its target.
+// Used by the upload and download commands. The planned paths aren't used as wildcard patterns, since they may include wildcard characters.
+func (plan *Plan) ToSpec() (*spec.SpecFiles, error) {
+	specFiles := &spec.SpecFiles{}
+	for _, operation := range plan.Operations {
+		file := spec.File{
+			Target:    operation.Target,
+			Props:     operation.Props,
+			Explode:   strconv.FormatBool(operation.Explode),
+			Flat:      "true",
+			Recursive: "false",
+		}
+		var err error
+		if plan.Command == "upload" {
+			var regexp bool
+			regexp, err = getExactLocalUploadPattern(operation.Source)
+			file.Pattern, file.Regexp = operation.Source, strconv.FormatBool(regexp)
+		} else {
+			file.Aql.ItemsFind, err = getPlannedItemQuery(operation)
+		}
+		if err != nil {
+			return nil, err
+		}
+		specFiles.Files = append(specFiles.Files, file)
+	}
+	return specFiles, nil
+}
+
+// Returns an AQL query, which finds exactly the planned item, rather than the items matched by its path as a wildcard pattern.
+func getPlannedItemQuery(operation PlanOperation) (string, error) {
+	repo, itemPath := operation.Source, "."
+	if i := strings.Index(repo, "/"); i >= 0 {
+		repo, itemPath = repo[:i], repo[i+1:]
+	}
+	dir, name := path.Split(itemPath)
+	criteria := map[string]string{"repo": repo, "path": strings.TrimSuffix(dir, "/"), "name": name}
+	if criteria["path"] == "" {
+		criteria["path"] = "."
+	}
+	if operation.Sha1 != "" {
+		criteria["actual_sha1"] = operation.Sha1
+	}
+	query, err := json.Marshal(criteria)
+	return string(query), errorutils.CheckError(err)
+}
+
+// Moves or copies the planned items to their planned targets. The items are the planned sources returned by VerifyPlan,
+// so that exactly the planned items are handled, rather than the items matched by their paths as wildcard patterns.
+func moveCopyPlannedItems(plan *Plan, plannedItems []clientutils.ResultItem, moveType services.MoveType, artDetails *config.ArtifactoryDetails, dryRun bool, detailedSummary *summary.Summary) (successCount, failCount int, err error) {
+	servicesManager, err := utils.CreateServiceManager(artDetails, dryRun)
+	if err != nil {
+		return 0, 0, err
+	}
+	targets := make(map[string]string)
+	for _, operation := range plan.Operations {
+		targets[operation.Source] = operation.Target
+	}
+	var items []targetItem
+	for _, item := range plannedItems {
+		items = append(items, targetItem{ResultItem: item, target: targets[item.GetItemRelativePath()]})
+	}
+	return moveCopyItems(servicesManager, moveType, items, detailedSummary)
+}
+
+// Verifies that the planned sources weren't changed since the plan was created, so that the plan is executed exactly as it was reviewed.
+// Returns the Artifactory items of the planned sources. For the upload command, the local files are verified and no items are returned.
+func VerifyPlan(plan *Plan, artDetails *config.ArtifactoryDetails) ([]clientutils.ResultItem, error) {
+	var changedSources []string
+	if plan.Command == "upload" {
+		for _, operation := range plan.Operations {
+			details, err := fileutils.GetFileDetails(operation.Source)
+			if err != nil || details.Checksum.Sha1 != operation.Sha1 {
+				changedSources = append(changedSources, operation.Source)
+			}
+		}
+		return nil, changedSourcesError(changedSources)
+	}
+	servicesManager, err := utils.CreateServiceManager(artDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	var resultItems []clientutils.ResultItem
+	for _, operation := range plan.Operations {
+		item, err := findPlannedItem(servicesManager, operation)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || item.Actual_Sha1 != operation.Sha1 {
+			changedSources = append(changedSources, operation.Source)
+			continue
+		}
+		resultItems = append(resultItems, *item)
+	}
+	return resultItems, changedSourcesError(changedSources)
+}
+
+// Returns the item in the source path of the operation, or nil if it doesn't exist.
+func findPlannedItem(servicesManager *artifactory.ArtifactoryServicesManager, operation PlanOperation) (*clientutils.ResultItem, error) {
+	// The path of a folder ends with a slash, which would match the folder content rather than the folder.
+	params := &clientutils.ArtifactoryCommonParams{Pattern: strings.TrimSuffix(operation.Source, "/"), Recursive: false, IncludeDirs: operation.Type == "folder"}
+	resultItems, err := servicesManager.Search(clientutils.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range resultItems {
+		if item.GetItemRelativePath() == operation.Source {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+func changedSourcesError(changedSources []string) error {
+	if len(changedSources) == 0 {
+		return nil
+	}
+	return errorutils.CheckError(errors.New("The plan cannot be executed, since the following paths were changed or removed since it was created:\n  " + strings.Join(changedSources, "\n  ")))
+}
+
+func addUploadedFilesToPlan(plan *Plan, filesInfo []clientutils.FileInfo, artifactoryUrl, props string, explode bool) {
+	for _, fileInfo := range filesInfo {
+		// The client joins the path of the uploaded file with the name of its target, so the path of the file is the parent of the reported local path.
+		localPath := filepath.Dir(fileInfo.LocalPath)
+		operation := PlanOperation{Source: localPath, Target: getUploadedRepoPath(fileInfo, artifactoryUrl), Props: props, Explode: explode}
+		if fileInfo.FileHashes != nil {
+			operation.Sha1 = fileInfo.Sha1
+			operation.Md5 = fileInfo.Md5
+		}
+		if stat, err := os.Stat(localPath); err == nil {
+			operation.Size = stat.Size()
+		}
+		plan.AddOperation(operation)
+	}
+}
+
+// The download doesn't return the files in dry run, so they are searched, and their local paths are calculated the same way the download does.
+// Returns the number of planned files.
+func addDownloadedFilesToPlan(plan *Plan, servicesManager *artifactory.ArtifactoryServicesManager, params *clientutils.ArtifactoryCommonParams, flat, explode bool) (int, error) {
+	resultItems, err := servicesManager.Search(clientutils.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return 0, err
+	}
+	planned := 0
+	for _, item := range resultItems {
+		if item.Type == "folder" {
+			continue
+		}
+		target, err := rtutils.BuildTargetPath(params.Pattern, item.GetItemRelativePath(), params.Target, true)
+		if err != nil {
+			return planned, err
+		}
+		localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat)
+		plan.AddOperation(PlanOperation{Source: item.GetItemRelativePath(), Target: filepath.Join(localPath, localFileName), Size: item.Size, Sha1: item.Actual_Sha1, Md5: item.Actual_Md5, Explode: explode})
+		planned++
+	}
+	return planned, nil
+}
+
+// Returns the number of planned files.
+func addMoveCopyItemsToPlan(plan *Plan, servicesManager *artifactory.ArtifactoryServicesManager, file *spec.File, flat bool) (int, error) {
+	items, err := getMoveCopyItems(servicesManager, file, flat)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range items {
+		plan.AddItem(item.ResultItem, item.target)
+	}
+	return len(items), nil
+}
+
+// Returns the items to be moved or copied by the spec file, with their target paths, for planning their move or copy.
+// The items are searched and reduced the same way the move and copy do, so that the plan shows where they would land.
+func getMoveCopyItems(servicesManager *artifactory.ArtifactoryServicesManager, file *spec.File, flat bool) ([]targetItem, error) {
+	params, err := file.ToArtifatoryMoveCopyParams()
+	if err != nil {
+		return nil, err
+	}
+	if params.GetSpecType() != clientutils.AQL {
+		params.IncludeDirs = true
+	}
+	resultItems, err := servicesManager.Search(clientutils.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return nil, err
+	}
+	if flat {
+		resultItems = clientutils.ReduceDirResult(resultItems, clientutils.FilterBottomChainResults)
+	} else {
+		resultItems = clientutils.ReduceDirResult(resultItems, clientutils.FilterTopChainResults)
+	}
+	var items []targetItem
+	for _, item := range resultItems {
+		target, err := getMoveCopyTarget(item, params.Pattern, params.Target, flat)
+		if err != nil {
+			target = ""
+		}
+		items = append(items, targetItem{ResultItem: item, target: target})
+	}
+	return items, nil
+}
+
+// Calculates the target path of a moved or copied item, the same way the move and copy do.
+func getMoveCopyTarget(item clientutils.ResultItem, pattern, target string, flat bool) (string, error) {
+	if !flat {
+		if strings.Contains(target, "/") {
+			file, dir := fileutils.GetFileAndDirFromPath(target)
+			target = rtutils.TrimPath(dir + "/" + item.Path + "/" + file)
+		} else {
+			target = rtutils.TrimPath(target + "/" + item.Path + "/")
+		}
+	}
+	targetPath, err := rtutils.BuildTargetPath(pattern, item.GetItemRelativePath(), target, true)
+	if err != nil {
+		return "", err
+	}
+	// A folder is moved into the target folder.
+	if strings.HasSuffix(targetPath, "/") && item.Type != "folder" {
+		targetPath += item.Name
+	}
+	return targetPath, nil
+}
@@ -0,0 +1,158 @@
+package generic
+
+import (
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadPlan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plan_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	plan := NewPlan("delete", "http://localhost:8081/artifactory/")
+	plan.AddItem(clientutils.ResultItem{Repo: "repo", Path: "a", Name: "b.zip", Type: "file", Size: 3, Actual_Sha1: "sha1"}, "")
+	plan.AddItem(clientutils.ResultItem{Repo: "repo", Path: "a", Name: "c", Type: "folder"}, "")
+	planPath := filepath.Join(dir, "plan.json")
+	if err = plan.Save(planPath); err != nil {
+		t.Fatal(err)
+	}
+
+	readPlan, err := ReadPlan(planPath, "delete", "http://localhost:8081/artifactory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []PlanOperation{{Source: "repo/a/b.zip", Size: 3, Sha1: "sha1"}, {Source: "repo/a/c/", Type: "folder"}}
+	if len(readPlan.Operations) != len(expected) {
+		t.Fatal("Unexpected operations:", readPlan.Operations)
+	}
+	for i, operation := range readPlan.Operations {
+		if operation != expected[i] {
+			t.Error("Expected", expected[i], "got", operation)
+		}
+	}
+
+	// A plan can only be executed by the command which created it, on the same server.
+	if _, err = ReadPlan(planPath, "move", "http://localhost:8081/artifactory/"); err == nil {
+		t.Error("Expected an error for a plan of another command.")
+	}
+	if _, err = ReadPlan(planPath, "delete", "http://other:8081/artifactory/"); err == nil {
+		t.Error("Expected an error for a plan of another server.")
+	}
+}
+
+func TestPlanToSpec(t *testing.T) {
+	plan := NewPlan("upload", "http://localhost:8081/artifactory/")
+	addUploadedFilesToPlan(plan, []clientutils.FileInfo{
+		{LocalPath: "a/b.zip/b.zip", ArtifactoryPath: "http://localhost:8081/artifactory/repo/b.zip;k=v", FileHashes: &clientutils.FileHashes{Sha1: "sha1", Md5: "md5"}},
+	}, plan.Url, "k=v", true)
+
+	expected := PlanOperation{Source: "a/b.zip", Target: "repo/b.zip", Sha1: "sha1", Md5: "md5", Props: "k=v", Explode: true}
+	if len(plan.Operations) != 1 || plan.Operations[0] != expected {
+		t.Fatal("Expected", expected, "got", plan.Operations)
+	}
+	planSpec, err := plan.ToSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(planSpec.Files) != 1 {
+		t.Fatal("Unexpected spec:", planSpec.Files)
+	}
+	file := planSpec.Get(0)
+	if file.Pattern != "a/b.zip" || file.Regexp != "false" || file.Target != "repo/b.zip" || file.Props != "k=v" || file.Explode != "true" || file.Flat != "true" || file.Recursive != "false" {
+		t.Error("Unexpected file group:", file)
+	}
+}
+
+// The planned paths are handled exactly, also if they include wildcard characters.
+func TestPlanToSpecWildcardPaths(t *testing.T) {
+	uploadPlan := NewPlan("upload", "http://localhost:8081/artifactory/")
+	uploadPlan.AddOperation(PlanOperation{Source: "a/*.zip", Target: "repo/*.zip"})
+	planSpec, err := uploadPlan.ToSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file := planSpec.Get(0); file.Pattern != "a/*.zip" || file.Regexp != "true" {
+		t.Error("Unexpected file group:", file)
+	}
+	uploadPlan.Operations[0].Source = "a/*(1).zip"
+	if _, err = uploadPlan.ToSpec(); err == nil {
+		t.Error("Expected an error for a path which cannot be uploaded exactly.")
+	}
+
+	downloadPlan := NewPlan("download", "http://localhost:8081/artifactory/")
+	downloadPlan.AddOperation(PlanOperation{Source: "repo/a/*.zip", Target: "out/*.zip", Sha1: "sha1"})
+	downloadPlan.AddOperation(PlanOperation{Source: "repo/b.zip", Target: "out/b.zip"})
+	planSpec, err = downloadPlan.ToSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{`{"actual_sha1":"sha1","name":"*.zip","path":"a","repo":"repo"}`, `{"name":"b.zip","path":".","repo":"repo"}`}
+	for i, file := range planSpec.Files {
+		if file.Pattern != "" || file.Aql.ItemsFind != expected[i] || file.Target != downloadPlan.Operations[i].Target {
+			t.Error("Unexpected file group:", file)
+		}
+	}
+}
+
+func TestVerifyUploadPlan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plan_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	localPath := filepath.Join(dir, "a.txt")
+	if err = ioutil.WriteFile(localPath, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	details, err := fileutils.GetFileDetails(localPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := NewPlan("upload", "http://localhost:8081/artifactory/")
+	plan.AddOperation(PlanOperation{Source: localPath, Target: "repo/a.txt", Sha1: details.Checksum.Sha1})
+	if _, err = VerifyPlan(plan, nil); err != nil {
+		t.Error("Expected the plan to be verified, got:", err)
+	}
+
+	// The plan isn't executed once the planned files change.
+	if err = ioutil.WriteFile(localPath, []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = VerifyPlan(plan, nil); err == nil {
+		t.Error("Expected an error for a changed file.")
+	}
+	plan.Operations[0].Source = filepath.Join(dir, "missing.txt")
+	if _, err = VerifyPlan(plan, nil); err == nil {
+		t.Error("Expected an error for a missing file.")
+	}
+}
+
+func TestGetMoveCopyTarget(t *testing.T) {
+	tests := []struct {
+		item     clientutils.ResultItem
+		target   string
+		flat     bool
+		expected string
+	}{
+		{clientutils.ResultItem{Repo: "repo", Path: "a/b", Name: "file.txt"}, "target/", true, "target/file.txt"},
+		{clientutils.ResultItem{Repo: "repo", Path: "a/b", Name: "file.txt"}, "target/", false, "target/a/b/file.txt"},
+		{clientutils.ResultItem{Repo: "repo", Path: "a", Name: "b", Type: "folder"}, "target/", true, "target/"},
+	}
+	for _, test := range tests {
+		target, err := getMoveCopyTarget(test.item, "repo/*", test.target, test.flat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target != test.expected {
+			t.Error("Expected the target", test.expected, "of", test.item.GetItemRelativePath(), "got:", target)
+		}
+	}
+}
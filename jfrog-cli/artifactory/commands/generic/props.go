@@ -4,40 +4,72 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-func SetProps(spec *spec.SpecFiles, props string, threads int, artDetails *config.ArtifactoryDetails) (successCount, failCount int, err error) {
-	servicesManager, err := createPropsServiceManager(threads, artDetails)
+// Sets the properties on the items found by the spec. If the detailed summary isn't nil, the result of each item is added to it.
+func SetProps(spec *spec.SpecFiles, props string, threads int, artDetails *config.ArtifactoryDetails, detailedSummary *summary.Summary) (successCount, failCount int, err error) {
+	resultItems, err := GetPropsItems(spec, artDetails)
 	if err != nil {
 		return 0, 0, err
 	}
+	return SetPropsOnItems(resultItems, props, threads, artDetails, detailedSummary)
+}
 
-	resultItems := searchItems(spec, servicesManager)
+func SetPropsOnItems(resultItems []clientutils.ResultItem, props string, threads int, artDetails *config.ArtifactoryDetails, detailedSummary *summary.Summary) (successCount, failCount int, err error) {
+	servicesManager, err := createPropsServiceManager(threads, artDetails)
+	if err != nil {
+		return 0, 0, err
+	}
+	if detailedSummary != nil {
+		// The properties are set on each item separately, so that the result of each item is added to the summary.
+		successCount, failCount = runOnItems(detailedSummary, toTargetItems(resultItems), threads, func(item targetItem) error {
+			_, err := servicesManager.SetProps(&services.PropsParamsImpl{Items: []clientutils.ResultItem{item.ResultItem}, Props: props})
+			return err
+		})
+		return successCount, failCount, nil
+	}
 	success, err := servicesManager.SetProps(&services.PropsParamsImpl{Items: resultItems, Props: props})
 	return success, len(resultItems) - success, err
 }
 
 func DeleteProps(spec *spec.SpecFiles, props string, threads int, artDetails *config.ArtifactoryDetails) (successCount, failCount int, err error) {
-	servicesManager, err := createPropsServiceManager(threads, artDetails)
+	resultItems, err := GetPropsItems(spec, artDetails)
 	if err != nil {
 		return 0, 0, err
 	}
+	return DeletePropsOnItems(resultItems, props, threads, artDetails)
+}
 
-	resultItems := searchItems(spec, servicesManager)
+func DeletePropsOnItems(resultItems []clientutils.ResultItem, props string, threads int, artDetails *config.ArtifactoryDetails) (successCount, failCount int, err error) {
+	servicesManager, err := createPropsServiceManager(threads, artDetails)
+	if err != nil {
+		return 0, 0, err
+	}
 	success, err := servicesManager.DeleteProps(&services.PropsParamsImpl{Items: resultItems, Props: props})
 	return success, len(resultItems) - success, err
 }
 
+// Returns the items found by the spec, whose properties are set or deleted.
+func GetPropsItems(spec *spec.SpecFiles, artDetails *config.ArtifactoryDetails) ([]clientutils.ResultItem, error) {
+	servicesManager, err := utils.CreateServiceManager(artDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	return searchItems(spec, servicesManager), nil
+}
+
 func createPropsServiceManager(threads int, artDetails *config.ArtifactoryDetails) (*artifactory.ArtifactoryServicesManager, error) {
 	certPath, err := utils.GetJfrogSecurityDir()
 	if err != nil {
 		return nil, err
 	}
-	artAuth, err := artDetails.CreateArtAuthConfig()
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return nil, err
 	}
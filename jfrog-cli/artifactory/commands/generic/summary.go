@@ -0,0 +1,104 @@
+package generic
+
+import (
+	"errors"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Adds the uploaded files to the detailed summary.
+// The client joins the path of the uploaded file with the name of its target, so the path of the file is the parent of the reported local path.
+func addUploadedFilesToSummary(detailedSummary *summary.Summary, filesInfo []clientutils.FileInfo, artifactoryUrl string, startTime time.Time) {
+	for _, fileInfo := range filesInfo {
+		localPath := filepath.Dir(fileInfo.LocalPath)
+		detailedSummary.AddResult(createSummaryFile(fileInfo, localPath, getUploadedRepoPath(fileInfo, artifactoryUrl), localPath), startTime, nil)
+	}
+}
+
+func addDownloadedFilesToSummary(detailedSummary *summary.Summary, filesInfo []clientutils.FileInfo, startTime time.Time) {
+	for _, fileInfo := range filesInfo {
+		detailedSummary.AddResult(createSummaryFile(fileInfo, fileInfo.ArtifactoryPath, fileInfo.LocalPath, fileInfo.LocalPath), startTime, nil)
+	}
+}
+
+// The client transfers the files of a spec group together, and reports only the files it transferred.
+// Therefore, the failure of the group is added to the detailed summary with the pattern and target of the group.
+func addFailedGroupToSummary(detailedSummary *summary.Summary, pattern, target string, failed int, err error, startTime time.Time) {
+	if err == nil && failed == 0 {
+		return
+	}
+	if err == nil {
+		err = errors.New("Failed transferring " + strconv.Itoa(failed) + " files. Please review the logs.")
+	}
+	detailedSummary.AddResult(summary.File{Source: pattern, Target: target}, startTime, err)
+}
+
+func createSummaryFile(fileInfo clientutils.FileInfo, source, target, localPath string) summary.File {
+	file := summary.File{Source: source, Target: target}
+	if fileInfo.FileHashes != nil {
+		file.Sha1 = fileInfo.Sha1
+		file.Md5 = fileInfo.Md5
+		file.Sha256 = fileInfo.Sha256
+	}
+	if stat, err := os.Stat(localPath); err == nil {
+		file.Size = stat.Size()
+	}
+	return file
+}
+
+// An Artifactory item handled by a command, with its target path.
+// The target is empty if the command has no target, or if it cannot be calculated.
+type targetItem struct {
+	clientutils.ResultItem
+	target string
+}
+
+func toTargetItems(resultItems []clientutils.ResultItem) []targetItem {
+	items := make([]targetItem, len(resultItems))
+	for i, item := range resultItems {
+		items[i] = targetItem{ResultItem: item}
+	}
+	return items
+}
+
+// Runs the action on each of the items, using the given number of threads, and adds the result of each item to the detailed summary, if it isn't nil.
+// Returns the number of items on which the action succeeded and failed.
+func runOnItems(detailedSummary *summary.Summary, items []targetItem, threads int, action func(item targetItem) error) (successCount, failCount int) {
+	var mutex sync.Mutex
+	runner := parallel.NewBounedRunner(threads, false)
+	go func() {
+		defer runner.Done()
+		for _, item := range items {
+			item := item
+			runner.AddTask(func(int) error {
+				startTime := time.Now()
+				err := action(item)
+				if detailedSummary != nil {
+					detailedSummary.AddResult(summary.File{
+						Source: item.GetItemRelativePath(),
+						Target: item.target,
+						Sha1:   item.Actual_Sha1,
+						Md5:    item.Actual_Md5,
+						Size:   item.Size,
+					}, startTime, err)
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err != nil {
+					failCount++
+					return err
+				}
+				successCount++
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	return
+}
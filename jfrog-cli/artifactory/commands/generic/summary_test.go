@@ -0,0 +1,34 @@
+package generic
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"testing"
+)
+
+func TestRunOnItems(t *testing.T) {
+	items := toTargetItems([]clientutils.ResultItem{{Repo: "repo", Path: "a", Name: "ok.txt"}, {Repo: "repo", Path: "a", Name: "failed.txt"}})
+	detailedSummary := summary.NewDetailed("delete")
+	success, failed := runOnItems(detailedSummary, items, 2, func(item targetItem) error {
+		if item.Name == "failed.txt" {
+			return errors.New("Artifactory response: 403 Forbidden")
+		}
+		return nil
+	})
+	if success != 1 || failed != 1 {
+		t.Error("Expected 1 success and 1 failure, got:", success, failed)
+	}
+	if len(detailedSummary.Files) != 2 {
+		t.Fatal("Expected the results of both items, got:", detailedSummary.Files)
+	}
+	for _, file := range detailedSummary.Files {
+		expectedError := ""
+		if file.Source == "repo/a/failed.txt" {
+			expectedError = "Artifactory response: 403 Forbidden"
+		}
+		if file.Error != expectedError {
+			t.Error("Unexpected error of", file.Source, "got:", file.Error)
+		}
+	}
+}
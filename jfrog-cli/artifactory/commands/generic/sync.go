@@ -0,0 +1,361 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	SyncUploadAction   = "upload"
+	SyncDownloadAction = "download"
+	SyncDeleteAction   = "delete"
+)
+
+// A single action of a sync plan.
+// For uploads and downloads, the source is the file to be transferred to the target.
+// For deletions, the target is the file to be deleted.
+type SyncAction struct {
+	Action string `json:"action"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target"`
+}
+
+// A local file to be uploaded to its target path.
+type syncUpload struct {
+	localPath string
+	target    string
+	props     string
+}
+
+// A file to be synced, keyed in the sync by its path relative to the synced directory.
+type syncFile struct {
+	path string
+	sha1 string
+	item clientutils.ResultItem
+}
+
+// Syncs the Artifactory paths in the spec targets with the local directories in the spec patterns.
+// Only new and modified files are uploaded. If the delete option is set, files in Artifactory which don't exist locally are deleted.
+func SyncUpload(syncSpec *spec.SpecFiles, configuration *SyncConfiguration) (successCount, failCount int, err error) {
+	servicesManager, err := createSyncServiceManager(configuration)
+	if err != nil {
+		return 0, 0, err
+	}
+	var errorOccurred = false
+	var plan []SyncAction
+	var uploads []syncUpload
+	for i := 0; i < len(syncSpec.Files); i++ {
+		file := syncSpec.Get(i)
+		params, err := file.ToArtifatorySearchParams()
+		if err != nil {
+			return 0, 0, err
+		}
+		recursive := params.Recursive
+		localDir, repoPath := file.Pattern, trimSlashes(file.Target)
+		localFiles, err := listLocalSyncFiles(localDir, recursive)
+		if err != nil {
+			return 0, 0, err
+		}
+		remoteFiles, err := listRemoteSyncFiles(servicesManager, repoPath, recursive)
+		if err != nil {
+			return 0, 0, err
+		}
+		transfer, remove := diffSyncFiles(localFiles, remoteFiles, configuration.Delete)
+		for _, relativePath := range transfer {
+			localPath := filepath.Join(localDir, filepath.FromSlash(relativePath))
+			target := path.Join(repoPath, relativePath)
+			plan = append(plan, SyncAction{Action: SyncUploadAction, Source: localPath, Target: target})
+			uploads = append(uploads, syncUpload{localPath: localPath, target: target, props: file.Props})
+		}
+		var deleteItems []services.DeleteItem
+		for _, relativePath := range remove {
+			item := remoteFiles[relativePath].item
+			plan = append(plan, SyncAction{Action: SyncDeleteAction, Target: item.GetItemRelativePath()})
+			deleteItems = append(deleteItems, item)
+		}
+		if configuration.DryRun || len(deleteItems) == 0 {
+			continue
+		}
+		deleted, err := servicesManager.DeleteFiles(deleteItems)
+		successCount += deleted
+		failCount += len(deleteItems) - deleted
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+		}
+	}
+	if configuration.DryRun {
+		// Nothing is transferred, so only the plan is reported.
+		return 0, 0, printSyncPlan(plan)
+	}
+	uploaded, failed := syncUploadFiles(servicesManager, uploads, configuration)
+	successCount += uploaded
+	failCount += failed
+	if errorOccurred || failed > 0 {
+		err = errors.New("Sync finished with errors. Please review the logs")
+	}
+	return
+}
+
+// Syncs the local directories in the spec targets with the Artifactory paths in the spec patterns.
+// Only new and modified files are downloaded. If the delete option is set, local files which don't exist in Artifactory are deleted.
+func SyncDownload(syncSpec *spec.SpecFiles, configuration *SyncConfiguration) (successCount, failCount int, err error) {
+	servicesManager, err := createSyncServiceManager(configuration)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !configuration.DryRun {
+		err = fileutils.CreateTempDirPath()
+		if err != nil {
+			return 0, 0, err
+		}
+		defer fileutils.RemoveTempDir()
+	}
+	var errorOccurred = false
+	var plan []SyncAction
+	for i := 0; i < len(syncSpec.Files); i++ {
+		file := syncSpec.Get(i)
+		params, err := file.ToArtifatorySearchParams()
+		if err != nil {
+			return 0, 0, err
+		}
+		recursive := params.Recursive
+		repoPath, localDir := trimSlashes(file.Pattern), file.Target
+		remoteFiles, err := listRemoteSyncFiles(servicesManager, repoPath, recursive)
+		if err != nil {
+			return 0, 0, err
+		}
+		localFiles, err := listLocalSyncFiles(localDir, recursive)
+		if err != nil {
+			return 0, 0, err
+		}
+		transfer, remove := diffSyncFiles(remoteFiles, localFiles, configuration.Delete)
+		for _, relativePath := range transfer {
+			plan = append(plan, SyncAction{Action: SyncDownloadAction, Source: remoteFiles[relativePath].item.GetItemRelativePath(), Target: filepath.Join(localDir, filepath.FromSlash(relativePath))})
+		}
+		for _, relativePath := range remove {
+			plan = append(plan, SyncAction{Action: SyncDeleteAction, Target: localFiles[relativePath].path})
+		}
+		if configuration.DryRun {
+			continue
+		}
+		if len(transfer) > 0 {
+			// The download skips files which exist locally with the same checksums, so only the modified files are transferred.
+			downloadParams := &clientutils.ArtifactoryCommonParams{
+				Pattern:   repoPath + "/(*)",
+				Target:    filepath.ToSlash(filepath.Join(localDir, "{1}")),
+				Recursive: recursive,
+			}
+			downloaded, expected, err := servicesManager.DownloadFiles(&services.DownloadParamsImpl{ArtifactoryCommonParams: downloadParams, Symlink: true, Flat: true, Retries: configuration.Retries})
+			failed := expected - len(downloaded)
+			successCount += len(transfer) - failed
+			failCount += failed
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+			}
+		}
+		for _, relativePath := range remove {
+			localPath := localFiles[relativePath].path
+			log.Info("Deleting", localPath)
+			if err = os.Remove(localPath); err != nil {
+				failCount++
+				errorOccurred = true
+				log.Error(errorutils.CheckError(err))
+				continue
+			}
+			successCount++
+		}
+	}
+	if configuration.DryRun {
+		// Nothing is transferred, so only the plan is reported.
+		return 0, 0, printSyncPlan(plan)
+	}
+	if errorOccurred {
+		err = errors.New("Sync finished with errors. Please review the logs")
+	}
+	return
+}
+
+// Compares the source files with the destination files by their relative paths and checksums.
+// Returns the sorted relative paths of the files to transfer, and of the files to delete from the destination.
+func diffSyncFiles(source, destination map[string]syncFile, includeDeletions bool) (transfer, remove []string) {
+	for relativePath, sourceFile := range source {
+		destinationFile, ok := destination[relativePath]
+		if !ok || destinationFile.sha1 != sourceFile.sha1 {
+			transfer = append(transfer, relativePath)
+		}
+	}
+	if includeDeletions {
+		for relativePath := range destination {
+			if _, ok := source[relativePath]; !ok {
+				remove = append(remove, relativePath)
+			}
+		}
+	}
+	sort.Strings(transfer)
+	sort.Strings(remove)
+	return
+}
+
+func listLocalSyncFiles(localDir string, recursive bool) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	exists, err := fileutils.IsDirExists(localDir, false)
+	if err != nil || !exists {
+		return files, err
+	}
+	err = filepath.Walk(localDir, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if !recursive && localPath != localDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, err := filepath.Rel(localDir, localPath)
+		if err != nil {
+			return err
+		}
+		details, err := fileutils.GetFileDetails(localPath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = syncFile{path: localPath, sha1: details.Checksum.Sha1}
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+func listRemoteSyncFiles(servicesManager *artifactory.ArtifactoryServicesManager, repoPath string, recursive bool) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	params := &clientutils.ArtifactoryCommonParams{Pattern: repoPath + "/*", Recursive: recursive}
+	resultItems, err := servicesManager.Search(clientutils.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range resultItems {
+		if item.Type == "folder" {
+			continue
+		}
+		relativePath := strings.TrimPrefix(item.GetItemRelativePath(), repoPath+"/")
+		files[relativePath] = syncFile{path: item.GetItemRelativePath(), sha1: item.Actual_Sha1, item: item}
+	}
+	return files, nil
+}
+
+// Uploads the files in parallel, using the number of threads of the configuration.
+// Returns the number of uploaded and failed files.
+func syncUploadFiles(servicesManager *artifactory.ArtifactoryServicesManager, uploads []syncUpload, configuration *SyncConfiguration) (uploaded, failed int) {
+	var mutex sync.Mutex
+	runner := parallel.NewBounedRunner(configuration.Threads, false)
+	go func() {
+		defer runner.Done()
+		for _, upload := range uploads {
+			upload := upload
+			runner.AddTask(func(int) error {
+				err := syncUploadFile(servicesManager, upload, configuration.Retries)
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err != nil {
+					failed++
+					log.Error(err)
+					return err
+				}
+				uploaded++
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	return
+}
+
+func syncUploadFile(servicesManager *artifactory.ArtifactoryServicesManager, upload syncUpload, retries int) error {
+	uploadParams, err := createSyncUploadParams(upload, retries)
+	if err != nil {
+		return err
+	}
+	_, _, failed, err := servicesManager.UploadFiles(uploadParams)
+	if err == nil && failed > 0 {
+		err = errorutils.CheckError(errors.New("Failed uploading " + upload.localPath + " to " + upload.target))
+	}
+	return err
+}
+
+// The upload pattern is the exact path of the file, and the target is the exact target path, since placeholders aren't replaced in the target of a single file.
+func createSyncUploadParams(upload syncUpload, retries int) (*services.UploadParamsImp, error) {
+	regexp, err := getExactLocalUploadPattern(upload.localPath)
+	if err != nil {
+		return nil, err
+	}
+	return &services.UploadParamsImp{
+		ArtifactoryCommonParams: &clientutils.ArtifactoryCommonParams{Pattern: upload.localPath, Target: upload.target, Props: upload.props, Regexp: regexp},
+		Flat:                    true,
+		Retries:                 retries,
+	}, nil
+}
+
+func printSyncPlan(plan []SyncAction) error {
+	if plan == nil {
+		plan = []SyncAction{}
+	}
+	content, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
+
+func createSyncServiceManager(configuration *SyncConfiguration) (*artifactory.ArtifactoryServicesManager, error) {
+	certPath, err := utils.GetJfrogSecurityDir()
+	if err != nil {
+		return nil, err
+	}
+	artAuth, err := configuration.ArtDetails.CreateArtAuthConfig(lock.LockConfig)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig, err := artifactory.NewConfigBuilder().
+		SetArtDetails(artAuth).
+		SetDryRun(configuration.DryRun).
+		SetCertificatesPath(certPath).
+		SetThreads(configuration.Threads).
+		SetLogger(log.Logger).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return artifactory.New(serviceConfig)
+}
+
+func trimSlashes(repoPath string) string {
+	return strings.TrimSuffix(repoPath, "/")
+}
+
+type SyncConfiguration struct {
+	Threads    int
+	Retries    int
+	DryRun     bool
+	Delete     bool
+	ArtDetails *config.ArtifactoryDetails
+}
@@ -0,0 +1,96 @@
+package generic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSyncFiles(t *testing.T) {
+	source := map[string]syncFile{
+		"unchanged":  {sha1: "1"},
+		"modified":   {sha1: "2"},
+		"a/new-file": {sha1: "3"},
+	}
+	destination := map[string]syncFile{
+		"unchanged": {sha1: "1"},
+		"modified":  {sha1: "0"},
+		"b/stale":   {sha1: "4"},
+	}
+
+	transfer, remove := diffSyncFiles(source, destination, false)
+	if !reflect.DeepEqual(transfer, []string{"a/new-file", "modified"}) {
+		t.Error("Unexpected files to transfer:", transfer)
+	}
+	if len(remove) != 0 {
+		t.Error("Expected no files to delete without the delete option, got:", remove)
+	}
+
+	_, remove = diffSyncFiles(source, destination, true)
+	if !reflect.DeepEqual(remove, []string{"b/stale"}) {
+		t.Error("Unexpected files to delete:", remove)
+	}
+}
+
+func TestListLocalSyncFiles(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "sync-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	if err = os.MkdirAll(filepath.Join(tempDir, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"root.txt", filepath.Join("a", "b", "nested.txt")} {
+		if err = ioutil.WriteFile(filepath.Join(tempDir, file), []byte("content"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := listLocalSyncFiles(tempDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files["a/b/nested.txt"].sha1 != "040f06fd774092478d450774f5ba30c5da78acc8" {
+		t.Error("Unexpected recursive listing:", files)
+	}
+
+	files, err = listLocalSyncFiles(tempDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files["root.txt"]; len(files) != 1 || !ok {
+		t.Error("Unexpected non-recursive listing:", files)
+	}
+
+	files, err = listLocalSyncFiles(filepath.Join(tempDir, "missing"), true)
+	if err != nil || len(files) != 0 {
+		t.Error("Expected an empty listing for a missing directory, got:", files, err)
+	}
+}
+
+func TestCreateSyncUploadParams(t *testing.T) {
+	tests := []struct {
+		localPath      string
+		expectedRegexp bool
+	}{
+		{"dir/file.txt", false},
+		{"dir/file (1).txt", false},
+		{"dir/{a}/file.txt", false},
+		{"dir/file*.txt", true},
+	}
+	for _, test := range tests {
+		params, err := createSyncUploadParams(syncUpload{localPath: test.localPath, target: "repo/file"}, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params.GetPattern() != test.localPath || params.GetTarget() != "repo/file" || params.IsRegexp() != test.expectedRegexp {
+			t.Error("Unexpected upload parameters for", test.localPath, "got:", params.ArtifactoryCommonParams)
+		}
+	}
+	if _, err := createSyncUploadParams(syncUpload{localPath: "dir/*(1).txt", target: "repo/file"}, 3); err == nil {
+		t.Error("Expected an error for a path which includes both '*' and '('.")
+	}
+}
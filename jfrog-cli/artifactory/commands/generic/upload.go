@@ -5,6 +5,8 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -13,8 +15,10 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Uploads the artifacts in the specified local path pattern to the specified target path.
@@ -28,6 +32,10 @@ func Upload(uploadSpec *spec.SpecFiles, flags *UploadConfiguration) (successCoun
 	if err != nil {
 		return 0, 0, err
 	}
+	if flags.Resume {
+		// Checksum deploy all files, so that files uploaded by the previous run are not transferred again.
+		minChecksumDeploySize = 0
+	}
 	servicesConfig, err := createUploadServiceConfig(flags.ArtDetails, flags, certPath, minChecksumDeploySize)
 	if err != nil {
 		return 0, 0, err
@@ -36,6 +44,13 @@ func Upload(uploadSpec *spec.SpecFiles, flags *UploadConfiguration) (successCoun
 	if err != nil {
 		return 0, 0, err
 	}
+	var checkpoint *uploadCheckpoint
+	if !flags.DryRun {
+		checkpoint, err = getUploadCheckpoint(uploadSpec, flags.ArtDetails.Url, flags.Resume)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
 	isCollectBuildInfo := len(flags.BuildName) > 0 && len(flags.BuildNumber) > 0
 	if isCollectBuildInfo && !flags.DryRun {
 		if err := utils.SaveBuildGeneralDetails(flags.BuildName, flags.BuildNumber); err != nil {
@@ -50,6 +65,13 @@ func Upload(uploadSpec *spec.SpecFiles, flags *UploadConfiguration) (successCoun
 	var filesInfo []clientutils.FileInfo
 	var errorOccurred = false
 	for i := 0; i < len(uploadSpec.Files); i++ {
+		if checkpoint != nil && checkpoint.isGroupCompleted(i) {
+			uploadedArtifacts := checkpoint.getArtifacts(i)
+			log.Info("Skipping", len(uploadedArtifacts), "artifacts of file spec", i+1, "which were uploaded by a previous run.")
+			filesInfo = append(filesInfo, uploadedArtifacts...)
+			successCount += len(uploadedArtifacts)
+			continue
+		}
 		params, err := uploadSpec.Get(i).ToArtifatoryUploadParams()
 		if err != nil {
 			errorOccurred = true
@@ -71,7 +93,22 @@ func Upload(uploadSpec *spec.SpecFiles, flags *UploadConfiguration) (successCoun
 			continue
 		}
 		uploadParamImp.ExplodeArchive = explode
+		startTime := time.Now()
 		artifacts, uploaded, failed, err := servicesManager.UploadFiles(uploadParamImp)
+		if flags.DetailedSummary != nil {
+			addUploadedFilesToSummary(flags.DetailedSummary, artifacts, flags.ArtDetails.Url, startTime)
+			addFailedGroupToSummary(flags.DetailedSummary, params.Pattern, params.Target, failed, err, startTime)
+		}
+		if checkpoint != nil {
+			if checkpointErr := checkpoint.update(i, artifacts, err == nil && failed == 0); checkpointErr != nil {
+				log.Warn("Failed to save the upload checkpoint:", checkpointErr.Error())
+			}
+			// Include the artifacts uploaded by previous runs in the build-info.
+			artifacts = checkpoint.getArtifacts(i)
+		}
+		if flags.Plan != nil {
+			addUploadedFilesToPlan(flags.Plan, artifacts, flags.ArtDetails.Url, params.Props, explode)
+		}
 		filesInfo = append(filesInfo, artifacts...)
 		failCount += failed
 		successCount += uploaded
@@ -89,30 +126,75 @@ func Upload(uploadSpec *spec.SpecFiles, flags *UploadConfiguration) (successCoun
 		return
 	}
 	if isCollectBuildInfo && !flags.DryRun {
-		buildArtifacts := convertFileInfoToBuildArtifacts(filesInfo)
-		populateFunc := func(partial *buildinfo.Partial) {
+		buildArtifacts := convertFileInfoToBuildArtifacts(filesInfo, flags.ArtDetails.Url, flags.ArtifactType)
+		populateFunc := func(partial *utils.Partial) {
 			partial.Artifacts = buildArtifacts
+			partial.ModuleId = flags.Module
 		}
 		err = utils.SavePartialBuildInfo(flags.BuildName, flags.BuildNumber, populateFunc)
+		if err != nil {
+			return
+		}
+	}
+	if checkpoint != nil {
+		err = checkpoint.remove()
 	}
 	return
 }
 
-func convertFileInfoToBuildArtifacts(filesInfo []clientutils.FileInfo) []buildinfo.Artifact {
-	buildArtifacts := make([]buildinfo.Artifact, len(filesInfo))
+// Converts the uploaded files to build-info artifacts.
+// If the artifact type is empty, the type of each artifact is inferred from its file extension.
+func convertFileInfoToBuildArtifacts(filesInfo []clientutils.FileInfo, artifactoryUrl, artifactType string) []utils.Artifact {
+	buildArtifacts := make([]utils.Artifact, len(filesInfo))
 	for i, fileInfo := range filesInfo {
-		artifact := buildinfo.Artifact{Checksum: &buildinfo.Checksum{}}
+		artifact := utils.Artifact{Artifact: buildinfo.Artifact{Checksum: &buildinfo.Checksum{}}}
 		artifact.Sha1 = fileInfo.Sha1
 		artifact.Md5 = fileInfo.Md5
 		filename, _ := fileutils.GetFileAndDirFromPath(fileInfo.LocalPath)
 		artifact.Name = filename
+		artifact.Path = getUploadedRepoPath(fileInfo, artifactoryUrl)
+		artifact.Type = artifactType
+		if artifact.Type == "" {
+			artifact.Type = getArtifactType(filename)
+		}
+		// The SHA256 is calculated by the client along with the other checksums of the uploaded file.
+		artifact.Sha256 = fileInfo.Sha256
 		buildArtifacts[i] = artifact
 	}
 	return buildArtifacts
 }
 
+// The Artifactory path of uploaded files is a full URL, which may also include the matrix params, so only the repository path is kept.
+// Returns whether the exact path of a local file is uploaded as a regular expression, so that the upload pattern matches only the file.
+// The path must not be cut by the wildcards of the pattern, since the upload then matches other files.
+// It is cut by '*' as a wildcard pattern, and by '(' as a regular expression, so the file is uploaded in the mode which doesn't cut it.
+func getExactLocalUploadPattern(localPath string) (regexp bool, err error) {
+	if !strings.Contains(localPath, "*") {
+		return false, nil
+	}
+	if strings.Contains(localPath, "(") {
+		return false, errorutils.CheckError(errors.New("Cannot upload exactly " + localPath + ", since its path includes both '*' and '('."))
+	}
+	return true, nil
+}
+
+func getUploadedRepoPath(fileInfo clientutils.FileInfo, artifactoryUrl string) string {
+	return strings.TrimPrefix(strings.SplitN(fileInfo.ArtifactoryPath, ";", 2)[0], artifactoryUrl)
+}
+
+// Returns the extension of the file, including the compression extension of tarballs.
+func getArtifactType(fileName string) string {
+	fileName = strings.ToLower(fileName)
+	for _, tarballExtension := range []string{".tar.gz", ".tar.bz2", ".tar.xz"} {
+		if strings.HasSuffix(fileName, tarballExtension) {
+			return strings.TrimPrefix(tarballExtension, ".")
+		}
+	}
+	return strings.TrimPrefix(filepath.Ext(fileName), ".")
+}
+
 func createUploadServiceConfig(artDetails *config.ArtifactoryDetails, flags *UploadConfiguration, certPath string, minChecksumDeploySize int64) (artifactory.Config, error) {
-	artAuth, err := artDetails.CreateArtAuthConfig()
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -170,9 +252,15 @@ type UploadConfiguration struct {
 	MinChecksumDeploySize int64
 	BuildName             string
 	BuildNumber           string
+	Module                string
+	ArtifactType          string
 	DryRun                bool
 	Symlink               bool
 	ExplodeArchive        bool
 	ArtDetails            *config.ArtifactoryDetails
 	Retries               int
+	Resume                bool
+	DetailedSummary       *summary.Summary
+	// If set, the files are added to the plan. The plan is created in dry run, so the files are not uploaded.
+	Plan *Plan
 }
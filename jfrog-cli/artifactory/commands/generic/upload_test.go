@@ -0,0 +1,75 @@
+package generic
+
+import (
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetArtifactType(t *testing.T) {
+	tests := map[string]string{
+		"a.zip":        "zip",
+		"a.JAR":        "jar",
+		"a-1.0.tar.gz": "tar.gz",
+		"a.tar.xz":     "tar.xz",
+		"a.1.gz":       "gz",
+		"README":       "",
+	}
+	for fileName, expected := range tests {
+		if actual := getArtifactType(fileName); actual != expected {
+			t.Errorf("Expected the type of %s to be '%s', got '%s'.", fileName, expected, actual)
+		}
+	}
+}
+
+func TestConvertFileInfoToBuildArtifacts(t *testing.T) {
+	artifactoryUrl := "http://localhost:8081/artifactory/"
+	filesInfo := []clientutils.FileInfo{{
+		LocalPath:       filepath.Join("dir", "a.txt"),
+		ArtifactoryPath: artifactoryUrl + "generic-local/dir/a.txt;build.name=name;build.number=1",
+		FileHashes:      &clientutils.FileHashes{Sha1: "sha1", Md5: "md5", Sha256: "sha256"},
+	}}
+
+	artifacts := convertFileInfoToBuildArtifacts(filesInfo, artifactoryUrl, "")
+	if len(artifacts) != 1 {
+		t.Fatal("Expected one artifact, got:", artifacts)
+	}
+	artifact := artifacts[0]
+	if artifact.Name != "a.txt" || artifact.Sha1 != "sha1" || artifact.Md5 != "md5" {
+		t.Error("Unexpected artifact details:", artifact)
+	}
+	if artifact.Path != "generic-local/dir/a.txt" {
+		t.Error("Unexpected artifact path:", artifact.Path)
+	}
+	if artifact.Type != "txt" {
+		t.Error("Unexpected artifact type:", artifact.Type)
+	}
+	if artifact.Sha256 != "sha256" {
+		t.Error("Unexpected artifact sha256:", artifact.Sha256)
+	}
+
+	artifacts = convertFileInfoToBuildArtifacts(filesInfo, artifactoryUrl, "text")
+	if artifacts[0].Type != "text" {
+		t.Error("Expected the artifact type to be overridden, got:", artifacts[0].Type)
+	}
+}
+
+func TestGetExactLocalUploadPattern(t *testing.T) {
+	tests := map[string]bool{
+		"dir/a.txt":    false,
+		"dir/a(1).txt": false,
+		"dir/*.txt":    true,
+	}
+	for localPath, expected := range tests {
+		regexp, err := getExactLocalUploadPattern(localPath)
+		if err != nil {
+			t.Error(err)
+		}
+		if regexp != expected {
+			t.Errorf("Expected the regexp of %s to be %t, got %t.", localPath, expected, regexp)
+		}
+	}
+	if _, err := getExactLocalUploadPattern("dir/*(1).txt"); err == nil {
+		t.Error("Expected an error for a path with both '*' and '('.")
+	}
+}
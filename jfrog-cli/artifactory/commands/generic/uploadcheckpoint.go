@@ -0,0 +1,160 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const checkpointsDirName = "checkpoints"
+
+// The upload checkpoint journal records the progress of an upload command, so that a failed upload
+// can be resumed by a later run of the same spec to the same Artifactory server.
+type uploadCheckpoint struct {
+	SpecHash string                  `json:"specHash,omitempty"`
+	Url      string                  `json:"url,omitempty"`
+	Groups   []uploadCheckpointGroup `json:"groups,omitempty"`
+	path     string
+}
+
+// Progress of a single file spec group.
+type uploadCheckpointGroup struct {
+	Completed bool                   `json:"completed,omitempty"`
+	Artifacts []clientutils.FileInfo `json:"artifacts,omitempty"`
+}
+
+func getCheckpointsDir() (string, error) {
+	homeDir, err := config.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, checkpointsDirName), nil
+}
+
+// The journal is keyed by the spec content and the Artifactory URL, which holds the upload targets.
+// The hash must be calculated before the build properties are added to the spec,
+// since they change between runs.
+func calcUploadSpecHash(uploadSpec *spec.SpecFiles, url string) (string, error) {
+	content, err := json.Marshal(uploadSpec)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	hash.Write([]byte(url))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns a new journal for the upload spec. If resume is true, the progress of a previous run is loaded.
+func getUploadCheckpoint(uploadSpec *spec.SpecFiles, url string, resume bool) (*uploadCheckpoint, error) {
+	specHash, err := calcUploadSpecHash(uploadSpec, url)
+	if err != nil {
+		return nil, err
+	}
+	checkpointsDir, err := getCheckpointsDir()
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &uploadCheckpoint{
+		SpecHash: specHash,
+		Url:      url,
+		path:     filepath.Join(checkpointsDir, "upload-"+specHash+".json"),
+	}
+	if resume {
+		if err = checkpoint.load(); err != nil {
+			return nil, err
+		}
+	}
+	// Make sure the journal is aligned with the spec, even if it was created by an older run.
+	if len(checkpoint.Groups) != len(uploadSpec.Files) {
+		checkpoint.Groups = make([]uploadCheckpointGroup, len(uploadSpec.Files))
+	}
+	return checkpoint, nil
+}
+
+func (checkpoint *uploadCheckpoint) load() error {
+	exists, err := fileutils.IsFileExists(checkpoint.path, false)
+	if err != nil || !exists {
+		return err
+	}
+	content, err := ioutil.ReadFile(checkpoint.path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	loaded := new(uploadCheckpoint)
+	if err = json.Unmarshal(content, loaded); err != nil {
+		// A corrupted journal should not fail the upload. It only means nothing can be skipped.
+		log.Warn("Ignoring an unreadable upload checkpoint at", checkpoint.path+":", err.Error())
+		return nil
+	}
+	if loaded.SpecHash != checkpoint.SpecHash {
+		return nil
+	}
+	checkpoint.Groups = loaded.Groups
+	return nil
+}
+
+func (checkpoint *uploadCheckpoint) isGroupCompleted(groupIndex int) bool {
+	return checkpoint.Groups[groupIndex].Completed
+}
+
+func (checkpoint *uploadCheckpoint) getArtifacts(groupIndex int) []clientutils.FileInfo {
+	return checkpoint.Groups[groupIndex].Artifacts
+}
+
+// Records the artifacts uploaded for the group and persists the journal.
+// Artifacts recorded by previous runs are kept, unless uploaded again to the same path.
+func (checkpoint *uploadCheckpoint) update(groupIndex int, artifacts []clientutils.FileInfo, completed bool) error {
+	group := &checkpoint.Groups[groupIndex]
+	group.Artifacts = mergeFilesInfo(group.Artifacts, artifacts)
+	group.Completed = completed
+	return checkpoint.save()
+}
+
+func (checkpoint *uploadCheckpoint) save() error {
+	err := fileutils.CreateDirIfNotExist(filepath.Dir(checkpoint.path))
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Write to a temp file first, so that a crash will never leave a truncated journal behind.
+	tempPath := checkpoint.path + ".tmp"
+	if err = ioutil.WriteFile(tempPath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.Rename(tempPath, checkpoint.path))
+}
+
+func (checkpoint *uploadCheckpoint) remove() error {
+	exists, err := fileutils.IsFileExists(checkpoint.path, false)
+	if err != nil || !exists {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(checkpoint.path))
+}
+
+func mergeFilesInfo(existing, added []clientutils.FileInfo) []clientutils.FileInfo {
+	indexes := make(map[string]int)
+	merged := make([]clientutils.FileInfo, 0, len(existing)+len(added))
+	for _, fileInfo := range append(existing, added...) {
+		if i, ok := indexes[fileInfo.ArtifactoryPath]; ok {
+			merged[i] = fileInfo
+			continue
+		}
+		indexes[fileInfo.ArtifactoryPath] = len(merged)
+		merged = append(merged, fileInfo)
+	}
+	return merged
+}
@@ -0,0 +1,111 @@
+package generic
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+const checkpointTestUrl = "http://localhost:8081/artifactory/"
+
+func TestUploadCheckpointResume(t *testing.T) {
+	cleanup := setTestJfrogHome(t)
+	defer cleanup()
+
+	uploadSpec := createCheckpointTestSpec()
+	checkpoint, err := getUploadCheckpoint(uploadSpec, checkpointTestUrl, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := clientutils.FileInfo{LocalPath: "a/1.zip", ArtifactoryPath: checkpointTestUrl + "repo/1.zip"}
+	second := clientutils.FileInfo{LocalPath: "b/2.zip", ArtifactoryPath: checkpointTestUrl + "repo/2.zip"}
+	if err = checkpoint.update(0, []clientutils.FileInfo{first}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = checkpoint.update(1, []clientutils.FileInfo{second}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := getUploadCheckpoint(uploadSpec, checkpointTestUrl, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resumed.isGroupCompleted(0) || resumed.isGroupCompleted(1) {
+		t.Error("Unexpected groups completion state:", resumed.Groups)
+	}
+	if artifacts := resumed.getArtifacts(1); len(artifacts) != 1 || artifacts[0].LocalPath != second.LocalPath {
+		t.Error("Expected the artifacts of the incomplete group to be loaded, got:", artifacts)
+	}
+
+	// Artifacts uploaded again should not be duplicated.
+	third := clientutils.FileInfo{LocalPath: "b/3.zip", ArtifactoryPath: checkpointTestUrl + "repo/3.zip"}
+	if err = resumed.update(1, []clientutils.FileInfo{second, third}, true); err != nil {
+		t.Fatal(err)
+	}
+	if artifacts := resumed.getArtifacts(1); len(artifacts) != 2 {
+		t.Error("Expected 2 artifacts, got:", artifacts)
+	}
+
+	if err = resumed.remove(); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := fileutils.IsFileExists(resumed.path, false); exists {
+		t.Error("Expected the checkpoint to be removed:", resumed.path)
+	}
+}
+
+func TestUploadCheckpointNoResume(t *testing.T) {
+	cleanup := setTestJfrogHome(t)
+	defer cleanup()
+
+	uploadSpec := createCheckpointTestSpec()
+	checkpoint, err := getUploadCheckpoint(uploadSpec, checkpointTestUrl, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = checkpoint.update(0, nil, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without resume, a previous run is ignored.
+	fresh, err := getUploadCheckpoint(uploadSpec, checkpointTestUrl, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.isGroupCompleted(0) {
+		t.Error("Expected a new checkpoint when not resuming.")
+	}
+
+	// A different target server should not share the checkpoint.
+	other, err := getUploadCheckpoint(uploadSpec, "http://other:8081/artifactory/", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.path == checkpoint.path || other.isGroupCompleted(0) {
+		t.Error("Expected a different checkpoint for a different Artifactory URL.")
+	}
+}
+
+func createCheckpointTestSpec() *spec.SpecFiles {
+	return &spec.SpecFiles{Files: []spec.File{
+		{Pattern: "a/*.zip", Target: "repo/"},
+		{Pattern: "b/*.zip", Target: "repo/"},
+	}}
+}
+
+func setTestJfrogHome(t *testing.T) func() {
+	tempDir, err := ioutil.TempDir("", "jfrog-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldHome := os.Getenv(config.JfrogHomeDirEnv)
+	os.Setenv(config.JfrogHomeDirEnv, tempDir)
+	return func() {
+		os.Setenv(config.JfrogHomeDirEnv, oldHome)
+		os.RemoveAll(tempDir)
+	}
+}
@@ -92,7 +92,10 @@ func ExecuteGo(noRegistry bool, goArg, targetRepo, buildName, buildNumber string
 	}
 
 	if !noRegistry {
-		goutils.SetGoProxyEnvVar(details, targetRepo)
+		err := goutils.SetGoProxyEnvVar(details, targetRepo)
+		if err != nil {
+			return err
+		}
 	}
 	err := goutils.RunGo(goArg)
 	if err != nil {
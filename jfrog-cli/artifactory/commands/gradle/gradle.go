@@ -59,7 +59,7 @@ func createGradleRunConfig(tasks, configPath string, configuration *utils.BuildC
 	runConfig := &gradleRunConfig{env: map[string]string{}}
 	runConfig.tasks = tasks
 
-	vConfig, err := utils.ReadConfigFile(configPath, utils.YAML)
+	vConfig, err := utils.ReadBuildConfig(configPath, utils.GRADLE, configuration.ProjectConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +117,7 @@ func createMvnRunConfig(goals, configPath string, configuration *utils.BuildConf
 	}
 
 	var vConfig *viper.Viper
-	vConfig, err = utils.ReadConfigFile(configPath, utils.YAML)
+	vConfig, err = utils.ReadBuildConfig(configPath, utils.MAVEN, configuration.ProjectConfig)
 	if err != nil {
 		return nil, err
 	}
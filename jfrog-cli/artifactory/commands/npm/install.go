@@ -9,6 +9,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/ioutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
@@ -265,8 +266,8 @@ func (npmi *npmInstall) collectDependenciesChecksums() error {
 func (npmi *npmInstall) saveDependenciesData() error {
 	log.Debug("Saving install data.")
 	dependencies, missingDependencies := npmi.transformDependencies()
-	populateFunc := func(partial *buildinfo.Partial) {
-		partial.Dependencies = dependencies
+	populateFunc := func(partial *utils.Partial) {
+		partial.Dependencies = utils.NewDependencies(dependencies)
 		partial.ModuleId = npmi.packageInfo.BuildInfoModuleId()
 	}
 
@@ -475,7 +476,7 @@ func (npmi *npmInstall) restoreNpmrcAndError(err error) error {
 }
 
 func (npmi *npmInstall) setArtifactoryAuth() error {
-	authArtDetails, err := npmi.cliConfig.ArtDetails.CreateArtAuthConfig()
+	authArtDetails, err := npmi.cliConfig.ArtDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
@@ -93,7 +94,7 @@ func (npmp *npmPublish) preparePrerequisites() error {
 		return err
 	}
 
-	artDetails, err := npmp.cliConfiguration.ArtDetails.CreateArtAuthConfig()
+	artDetails, err := npmp.cliConfiguration.ArtDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return err
 	}
@@ -166,8 +167,8 @@ func (npmp *npmPublish) saveArtifactData() error {
 		buildArtifacts = append(buildArtifacts, artifact.ToBuildArtifacts())
 	}
 
-	populateFunc := func(partial *buildinfo.Partial) {
-		partial.Artifacts = buildArtifacts
+	populateFunc := func(partial *utils.Partial) {
+		partial.Artifacts = utils.NewArtifacts(buildArtifacts)
 		partial.ModuleId = npmp.packageInfo.BuildInfoModuleId()
 	}
 	return utils.SavePartialBuildInfo(npmp.cliConfiguration.BuildName, npmp.cliConfiguration.BuildNumber, populateFunc)
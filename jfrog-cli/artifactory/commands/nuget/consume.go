@@ -6,6 +6,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/nuget"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/nuget/solution"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -270,8 +271,7 @@ func getSourceDetails(params *Params) (sourceURL, user, password string, err err
 	}
 	u.Path = path.Join(u.Path, "api/nuget", params.RepoName)
 	sourceURL = u.String()
-	user = params.ArtifactoryDetails.User
-	password = params.ArtifactoryDetails.Password
+	user, password, err = params.ArtifactoryDetails.GetBasicAuthCredentials(lock.LockConfig)
 	return
 }
 
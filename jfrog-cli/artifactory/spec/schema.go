@@ -0,0 +1,92 @@
+package spec
+
+// The JSON Schema of the File Specs, which editors can use for completing and validating specs.
+// It is printed by 'jfrog rt spec-validate --schema'.
+// The fields and their types must match the fields checked by ValidateSpecFile, as verified by TestSchemaFields.
+const Schema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://github.com/jfrog/jfrog-cli-go/filespec.schema.json",
+  "title": "JFrog CLI File Spec",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "include": {
+      "description": "Paths of spec files, in JSON or YAML format, whose file groups are included before the file groups of this spec. Relative paths are relative to the directory of this spec.",
+      "type": "array",
+      "items": {"type": "string"}
+    },
+    "files": {
+      "type": "array",
+      "minItems": 1,
+      "items": {"$ref": "#/definitions/file"}
+    }
+  },
+  "anyOf": [
+    {"required": ["files"]},
+    {"required": ["include"]}
+  ],
+  "definitions": {
+    "boolean": {
+      "type": "string",
+      "pattern": "^(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)$|\\$\\{[^}]+\\}"
+    },
+    "aqlCriteria": {
+      "type": "object",
+      "properties": {
+        "$and": {"type": "array", "items": {"$ref": "#/definitions/aqlCriteria"}},
+        "$or": {"type": "array", "items": {"$ref": "#/definitions/aqlCriteria"}},
+        "$msp": {"type": "array", "items": {"$ref": "#/definitions/aqlCriteria"}}
+      },
+      "patternProperties": {
+        "^[^$]": {
+          "oneOf": [
+            {"type": ["string", "number", "boolean"]},
+            {
+              "type": "object",
+              "additionalProperties": false,
+              "propertyNames": {"enum": ["$eq", "$ne", "$gt", "$gte", "$lt", "$lte", "$match", "$nmatch"]},
+              "patternProperties": {"": {"type": ["string", "number", "boolean"]}}
+            }
+          ]
+        }
+      },
+      "additionalProperties": false
+    },
+    "file": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "aql": {
+          "description": "An AQL query, which finds the files in Artifactory.",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["items.find"],
+          "properties": {"items.find": {"$ref": "#/definitions/aqlCriteria"}}
+        },
+        "pattern": {"description": "Wildcard or regular expression pattern of the files.", "type": "string"},
+        "excludePatterns": {"type": "array", "items": {"type": "string"}},
+        "target": {"description": "The target path of the files.", "type": "string"},
+        "explode": {"$ref": "#/definitions/boolean"},
+        "props": {"description": "Properties in the form of \"key1=value1;key2=value2,...\".", "type": "string"},
+        "sortOrder": {"type": "string", "enum": ["asc", "desc"]},
+        "sortBy": {"type": "array", "items": {"type": "string"}},
+        "offset": {"type": "integer", "minimum": 0},
+        "limit": {"type": "integer", "minimum": 0},
+        "build": {"description": "A build in the form of \"name/number\".", "type": "string"},
+        "recursive": {"$ref": "#/definitions/boolean"},
+        "flat": {"$ref": "#/definitions/boolean"},
+        "regexp": {"$ref": "#/definitions/boolean"},
+        "includeDirs": {"$ref": "#/definitions/boolean"},
+        "archiveEntries": {"type": "string"}
+      },
+      "oneOf": [
+        {"required": ["pattern"]},
+        {"required": ["aql"]}
+      ],
+      "dependencies": {
+        "sortOrder": ["sortBy"]
+      }
+    }
+  }
+}
+`
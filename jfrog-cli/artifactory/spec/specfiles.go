@@ -10,6 +10,12 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 type SpecFiles struct {
@@ -23,33 +29,158 @@ func (spec *SpecFiles) Get(index int) *File {
 	return new(File)
 }
 
+// Reads a File Spec in JSON or YAML format. YAML is detected by the .yaml or .yml extension.
+// The variables referenced as ${name} are replaced by the received spec vars, or by the environment variables.
+// A default value can be given as ${name:-default}, and $${name} is left as ${name}.
+// The spec may include the file groups of other spec files, in either format, by listing their paths under 'include',
+// relative to the directory of the including spec.
 func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *SpecFiles, err error) {
-	spec = new(SpecFiles)
+	return readSpecFile(specFilePath, specVars, map[string]bool{})
+}
+
+// The content of a spec file, before its includes are resolved.
+type specFileContent struct {
+	Include []string
+	Files   []File
+}
+
+func readSpecFile(specFilePath string, specVars map[string]string, including map[string]bool) (*SpecFiles, error) {
+	absolutePath, err := filepath.Abs(specFilePath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if including[absolutePath] {
+		return nil, errorutils.CheckError(errors.New("The File Spec " + specFilePath + " includes itself."))
+	}
 	content, err := fileutils.ReadFile(specFilePath)
 	if errorutils.CheckError(err) != nil {
-		return
+		return nil, err
 	}
-
-	if len(specVars) > 0 {
-		content = replaceSpecVars(content, specVars)
+	content, err = replaceSpecVars(content, specVars)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed to read the File Spec " + specFilePath + ": " + err.Error()))
 	}
-
-	err = json.Unmarshal(content, spec)
+	if isYamlSpec(specFilePath) {
+		content, err = convertYamlSpecToJson(content)
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("Failed to parse the File Spec " + specFilePath + ": " + err.Error()))
+		}
+	}
+	specContent := new(specFileContent)
+	err = json.Unmarshal(content, specContent)
 	if errorutils.CheckError(err) != nil {
-		return
+		return nil, err
 	}
-	return
+
+	spec := new(SpecFiles)
+	including[absolutePath] = true
+	for _, include := range specContent.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(specFilePath), include)
+		}
+		includedSpec, err := readSpecFile(include, specVars, including)
+		if err != nil {
+			return nil, err
+		}
+		spec.Files = append(spec.Files, includedSpec.Files...)
+	}
+	delete(including, absolutePath)
+	spec.Files = append(spec.Files, specContent.Files...)
+	return spec, nil
 }
 
-func replaceSpecVars(content []byte, specVars map[string]string) []byte {
+func isYamlSpec(specFilePath string) bool {
+	extension := strings.ToLower(filepath.Ext(specFilePath))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// Matches ${name}, ${name:-default} and the escaped form $${name}.
+var specVarRegexp = regexp.MustCompile(`\$?\$\{([^}:]+)(:-([^}]*))?\}`)
+
+// Replaces the variables with the spec vars, the environment variables or their default values, in that order.
+// Returns an error listing the variables which could not be resolved.
+func replaceSpecVars(content []byte, specVars map[string]string) ([]byte, error) {
 	log.Debug("Replacing variables in the provided File Spec: \n" + string(content))
-	for key, val := range specVars {
-		key = "${" + key + "}"
-		log.Debug(fmt.Sprintf("Replacing '%s' with '%s'", key, val))
-		content = bytes.Replace(content, []byte(key), []byte(val), -1)
+	var unresolved []string
+	content = specVarRegexp.ReplaceAllFunc(content, func(reference []byte) []byte {
+		if bytes.HasPrefix(reference, []byte("$$")) {
+			return reference[1:]
+		}
+		match := specVarRegexp.FindSubmatch(reference)
+		key := string(match[1])
+		if val, exists := specVars[key]; exists {
+			log.Debug(fmt.Sprintf("Replacing '%s' with '%s'", reference, val))
+			return []byte(val)
+		}
+		if val, exists := os.LookupEnv(key); exists {
+			log.Debug(fmt.Sprintf("Replacing '%s' with the value of the environment variable", reference))
+			return []byte(val)
+		}
+		if len(match[2]) > 0 {
+			log.Debug(fmt.Sprintf("Replacing '%s' with its default value", reference))
+			return match[3]
+		}
+		unresolved = append(unresolved, key)
+		return reference
+	})
+	if len(unresolved) > 0 {
+		return nil, errors.New("Unresolved variables: " + strings.Join(unresolved, ", ") + ". Set them using the --spec-vars option or as environment variables, or give them default values as ${name:-default}.")
 	}
 	log.Debug("The reformatted File Spec is: \n" + string(content))
-	return content
+	return content, nil
+}
+
+// Converts a YAML spec to JSON, which can be read into the spec structs. Boolean values of the file groups are converted
+// to strings, since the file groups hold them as strings, as written in JSON specs.
+func convertYamlSpecToJson(content []byte) ([]byte, error) {
+	var yamlSpec interface{}
+	err := yaml.Unmarshal(content, &yamlSpec)
+	if err != nil {
+		return nil, err
+	}
+	jsonSpec := convertYamlValue(yamlSpec)
+	if specMap, ok := jsonSpec.(map[string]interface{}); ok {
+		for key, value := range specMap {
+			if strings.ToLower(key) != "files" {
+				continue
+			}
+			files, _ := value.([]interface{})
+			for _, file := range files {
+				if fileMap, ok := file.(map[string]interface{}); ok {
+					for fileKey, fileValue := range fileMap {
+						if boolValue, ok := fileValue.(bool); ok {
+							fileMap[fileKey] = strconv.FormatBool(boolValue)
+						}
+					}
+				}
+			}
+		}
+	}
+	return json.Marshal(jsonSpec)
+}
+
+// YAML maps with non-string keys are read with keys of any type, while JSON requires string keys.
+func convertYamlValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, mapValue := range typedValue {
+			typedValue[key] = convertYamlValue(mapValue)
+		}
+		return typedValue
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{})
+		for key, mapValue := range typedValue {
+			converted[fmt.Sprint(key)] = convertYamlValue(mapValue)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typedValue))
+		for i, sliceValue := range typedValue {
+			converted[i] = convertYamlValue(sliceValue)
+		}
+		return converted
+	}
+	return value
 }
 
 type File struct {
@@ -68,7 +199,7 @@ type File struct {
 	Flat            string
 	Regexp          string
 	IncludeDirs     string
-	ArchiveEntries string
+	ArchiveEntries  string
 }
 
 func (f File) IsFlat(defaultValue bool) (bool, error) {
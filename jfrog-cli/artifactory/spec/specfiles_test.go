@@ -2,41 +2,103 @@ package spec
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
 func TestReplaceSpecVars(t *testing.T) {
-	var actual []byte
-	actual = replaceSpecVars([]byte("${foo}aa"), map[string]string{"a": "k", "foo": "bar"})
-	assertVariablesMap([]byte("baraa"), actual, t)
+	assertReplacedVars(t, "${foo}aa", map[string]string{"a": "k", "foo": "bar"}, "baraa")
+	assertReplacedVars(t, "a${foo}a", map[string]string{"foo": "bar"}, "abara")
+	assertReplacedVars(t, "aa${foo}", map[string]string{"foo": "bar"}, "aabar")
+	assertReplacedVars(t, "${foo}${foo}${foo}", map[string]string{"foo": "bar"}, "barbarbar")
+	assertReplacedVars(t, "${talk}-${broh}-${foo}", map[string]string{"foo": "bar", "talk": "speak", "broh": "sroh"}, "speak-sroh-bar")
+	assertReplacedVars(t, "a${foo}a", map[string]string{"foo": ""}, "aa")
+	assertReplacedVars(t, "", nil, "")
 
-	actual = replaceSpecVars([]byte("a${foo}a"), map[string]string{"foo": "bar"})
-	assertVariablesMap([]byte("abara"), actual, t)
+	// Default values, environment variables and escaped variables.
+	os.Setenv("SPEC_TEST_VAR", "env")
+	defer os.Unsetenv("SPEC_TEST_VAR")
+	assertReplacedVars(t, "a${foo:-default}a", nil, "adefaulta")
+	assertReplacedVars(t, "a${foo:-}a", nil, "aa")
+	assertReplacedVars(t, "a${foo:-default}a", map[string]string{"foo": "bar"}, "abara")
+	assertReplacedVars(t, "${SPEC_TEST_VAR}-${SPEC_TEST_VAR:-default}", nil, "env-env")
+	assertReplacedVars(t, "${SPEC_TEST_VAR}", map[string]string{"SPEC_TEST_VAR": "var"}, "var")
+	assertReplacedVars(t, "$${foo}", nil, "${foo}")
 
-	actual = replaceSpecVars([]byte("aa${foo}"), map[string]string{"foo": "bar"})
-	assertVariablesMap([]byte("aabar"), actual, t)
-
-	actual = replaceSpecVars([]byte("${foo}${foo}${foo}"), map[string]string{"foo": "bar"})
-	assertVariablesMap([]byte("barbarbar"), actual, t)
-
-	actual = replaceSpecVars([]byte("${talk}-${broh}-${foo}"), map[string]string{"foo": "bar", "talk": "speak", "broh": "sroh"})
-	assertVariablesMap([]byte("speak-sroh-bar"), actual, t)
+	// Unresolved variables are errors, rather than being sent to Artifactory as is.
+	for _, content := range []string{"a${foo}a", "${foo}-${bar:-bar}"} {
+		if _, err := replaceSpecVars([]byte(content), map[string]string{"a": "k", "f": "a"}); err == nil {
+			t.Error("Expected an error for the unresolved variables of", content)
+		}
+	}
+}
 
-	actual = replaceSpecVars([]byte("a${foo}a"), map[string]string{"foo": ""})
-	assertVariablesMap([]byte("aa"), actual, t)
+func assertReplacedVars(t *testing.T, content string, specVars map[string]string, expected string) {
+	actual, err := replaceSpecVars([]byte(content), specVars)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if 0 != bytes.Compare([]byte(expected), actual) {
+		t.Error("Wrong matching expected: `" + expected + "` Got `" + string(actual) + "`")
+	}
+}
 
-	actual = replaceSpecVars([]byte("a${foo}a"), map[string]string{"a": "k", "f": "a"})
-	assertVariablesMap([]byte("a${foo}a"), actual, t)
+func TestCreateYamlSpecWithIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeSpecFile(t, filepath.Join(dir, "common", "common.json"), `{"files": [{"pattern": "${repo}/common/", "flat": "true"}]}`)
+	writeSpecFile(t, filepath.Join(dir, "spec.yaml"), `include:
+    - common/common.json
+files:
+    - pattern: ${repo}/*.zip
+      target: out/
+      flat: false
+      offset: 2
+    - aql:
+          items.find:
+              repo: ${repo}
+`)
+	spec, err := CreateSpecFromFile(filepath.Join(dir, "spec.yaml"), map[string]string{"repo": "libs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Files) != 3 {
+		t.Fatal("Expected 3 file groups, got:", spec.Files)
+	}
+	if spec.Get(0).Pattern != "libs/common/" || spec.Get(0).Flat != "true" {
+		t.Error("Unexpected included file group:", spec.Get(0))
+	}
+	if spec.Get(1).Pattern != "libs/*.zip" || spec.Get(1).Target != "out/" || spec.Get(1).Flat != "false" || spec.Get(1).Offset != 2 {
+		t.Error("Unexpected file group:", spec.Get(1))
+	}
+	if spec.Get(2).Aql.ItemsFind != `{"repo":"libs"}` {
+		t.Error("Unexpected aql:", spec.Get(2).Aql.ItemsFind)
+	}
 
-	actual = replaceSpecVars([]byte("a${foo}a"), map[string]string{})
-	assertVariablesMap([]byte("a${foo}a"), actual, t)
+	// The unresolved variables are reported with the path of the spec.
+	if _, err := CreateSpecFromFile(filepath.Join(dir, "spec.yaml"), nil); err == nil {
+		t.Error("Expected an error for the unresolved repo variable.")
+	}
 
-	actual = replaceSpecVars(nil, nil)
-	assertVariablesMap([]byte(""), actual, t)
+	// A spec can't include itself.
+	writeSpecFile(t, filepath.Join(dir, "a.yml"), "include: [b.yml]\n")
+	writeSpecFile(t, filepath.Join(dir, "b.yml"), "include: [a.yml]\n")
+	if _, err := CreateSpecFromFile(filepath.Join(dir, "a.yml"), nil); err == nil {
+		t.Error("Expected an error for a spec including itself.")
+	}
 }
 
-func assertVariablesMap(expected, actual []byte, t *testing.T) {
-	if 0 != bytes.Compare(expected, actual) {
-		t.Error("Wrong matching expected: `" + string(expected) + "` Got `" + string(actual) + "`")
+func writeSpecFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
 	}
 }
@@ -0,0 +1,222 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+type nodeKind int
+
+const (
+	objectNode nodeKind = iota
+	arrayNode
+	scalarNode
+)
+
+// A value of a spec file, with its position in the file, used for reporting validation errors.
+type specNode struct {
+	kind nodeKind
+	// The members of an object, in the order they appear in the file.
+	members []*specMember
+	// The items of an array.
+	items []*specNode
+	// The value of a scalar: a string, a bool, a json.Number for JSON specs, an int or a float64 for YAML specs, or nil.
+	value    interface{}
+	position position
+}
+
+type specMember struct {
+	key         string
+	keyPosition position
+	value       *specNode
+}
+
+// A 1-based line and column in the spec file. The line is 0 if the position is unknown, and the column is 0 if only the line is known.
+type position struct {
+	line   int
+	column int
+}
+
+func (p position) String() string {
+	if p.line == 0 {
+		return ""
+	}
+	if p.column == 0 {
+		return strconv.Itoa(p.line)
+	}
+	return strconv.Itoa(p.line) + ":" + strconv.Itoa(p.column)
+}
+
+func offsetToPosition(content []byte, offset int) position {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	line := bytes.Count(content[:offset], []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	return position{line: line, column: offset - lineStart + 1}
+}
+
+// A syntax error of the spec file, at a position if it is known.
+type syntaxError struct {
+	position position
+	message  string
+}
+
+func (err *syntaxError) Error() string {
+	return err.message
+}
+
+func parseJsonSpecNode(content []byte) (*specNode, error) {
+	parser := &jsonNodeParser{decoder: json.NewDecoder(bytes.NewReader(content)), content: content}
+	parser.decoder.UseNumber()
+	node, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if _, tokenPosition, err := parser.nextToken(); err != io.EOF {
+		return nil, &syntaxError{position: tokenPosition, message: "Unexpected content after the end of the spec."}
+	}
+	return node, nil
+}
+
+type jsonNodeParser struct {
+	decoder *json.Decoder
+	content []byte
+}
+
+// Returns the next token and the position it starts at.
+func (parser *jsonNodeParser) nextToken() (json.Token, position, error) {
+	// The decoder offset is the end of the previous token, which may be followed by white space and separators.
+	offset := int(parser.decoder.InputOffset())
+	for offset < len(parser.content) && bytes.IndexByte([]byte(" \t\r\n,:"), parser.content[offset]) >= 0 {
+		offset++
+	}
+	tokenPosition := offsetToPosition(parser.content, offset)
+	token, err := parser.decoder.Token()
+	if err == io.EOF {
+		return nil, tokenPosition, err
+	}
+	if err != nil {
+		if jsonSyntaxError, ok := err.(*json.SyntaxError); ok {
+			tokenPosition = offsetToPosition(parser.content, int(jsonSyntaxError.Offset))
+		}
+		return nil, tokenPosition, &syntaxError{position: tokenPosition, message: err.Error()}
+	}
+	return token, tokenPosition, nil
+}
+
+func (parser *jsonNodeParser) parseValue() (*specNode, error) {
+	token, tokenPosition, err := parser.nextToken()
+	if err == io.EOF {
+		return nil, &syntaxError{position: tokenPosition, message: "Unexpected end of the spec."}
+	}
+	if err != nil {
+		return nil, err
+	}
+	node := &specNode{position: tokenPosition}
+	switch token {
+	case json.Delim('{'):
+		node.kind = objectNode
+		for parser.decoder.More() {
+			keyToken, keyPosition, err := parser.nextToken()
+			if err != nil {
+				return nil, err
+			}
+			value, err := parser.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			node.members = append(node.members, &specMember{key: keyToken.(string), keyPosition: keyPosition, value: value})
+		}
+		// The closing delimiter.
+		_, _, err = parser.nextToken()
+	case json.Delim('['):
+		node.kind = arrayNode
+		for parser.decoder.More() {
+			item, err := parser.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			node.items = append(node.items, item)
+		}
+		_, _, err = parser.nextToken()
+	default:
+		node.kind = scalarNode
+		node.value = token
+	}
+	return node, err
+}
+
+// The YAML syntax errors include only the line of the error.
+var yamlErrorLineRegexp = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+func parseYamlSpecNode(content []byte) (*specNode, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		specSyntaxError := &syntaxError{message: err.Error()}
+		if match := yamlErrorLineRegexp.FindStringSubmatch(err.Error()); match != nil {
+			specSyntaxError.position.line, _ = strconv.Atoi(match[1])
+			specSyntaxError.message = match[2]
+		}
+		return nil, specSyntaxError
+	}
+	// An empty spec has no document content.
+	if len(document.Content) == 0 {
+		return &specNode{kind: scalarNode}, nil
+	}
+	return newYamlSpecNode(document.Content[0])
+}
+
+func newYamlSpecNode(yamlNode *yaml.Node) (*specNode, error) {
+	nodePosition := position{line: yamlNode.Line, column: yamlNode.Column}
+	switch yamlNode.Kind {
+	case yaml.AliasNode:
+		node, err := newYamlSpecNode(yamlNode.Alias)
+		if err != nil {
+			return nil, err
+		}
+		// The errors of the value are reported where it is used.
+		node.position = nodePosition
+		return node, nil
+	case yaml.MappingNode:
+		node := &specNode{kind: objectNode, position: nodePosition}
+		for i := 0; i+1 < len(yamlNode.Content); i += 2 {
+			key, value := yamlNode.Content[i], yamlNode.Content[i+1]
+			memberValue, err := newYamlSpecNode(value)
+			if err != nil {
+				return nil, err
+			}
+			node.members = append(node.members, &specMember{key: key.Value, keyPosition: position{line: key.Line, column: key.Column}, value: memberValue})
+		}
+		return node, nil
+	case yaml.SequenceNode:
+		node := &specNode{kind: arrayNode, position: nodePosition}
+		for _, item := range yamlNode.Content {
+			itemNode, err := newYamlSpecNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.items = append(node.items, itemNode)
+		}
+		return node, nil
+	}
+	node := &specNode{kind: scalarNode, position: nodePosition}
+	if err := yamlNode.Decode(&node.value); err != nil {
+		return nil, &syntaxError{position: nodePosition, message: err.Error()}
+	}
+	return node, nil
+}
+
+// Returns the value of a string scalar, and false if the node isn't a string.
+func (node *specNode) stringValue() (string, bool) {
+	if node.kind != scalarNode {
+		return "", false
+	}
+	value, ok := node.value.(string)
+	return value, ok
+}
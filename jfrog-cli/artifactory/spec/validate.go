@@ -0,0 +1,545 @@
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An issue found in a spec file by ValidateSpecFile.
+type ValidationError struct {
+	File string
+	// The 1-based line and column of the issue. They are 0 if the position is unknown, and the column is 0 for the YAML syntax errors.
+	Line   int
+	Column int
+	// The location of the value in the spec, such as files[0].pattern.
+	Path    string
+	Message string
+}
+
+func (validationError ValidationError) String() string {
+	location := validationError.File
+	if validationError.Line > 0 {
+		location += ":" + position{line: validationError.Line, column: validationError.Column}.String()
+	}
+	if validationError.Path != "" {
+		location += ": " + validationError.Path
+	}
+	return location + ": " + validationError.Message
+}
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	boolField
+	intField
+	stringArrayField
+	aqlField
+)
+
+// The fields of a file group, as named in the spec files. The names are matched case-insensitively, as json.Unmarshal does.
+// Fields added here should also be added to the Schema.
+var specFileFields = map[string]fieldType{
+	"aql":             aqlField,
+	"pattern":         stringField,
+	"excludePatterns": stringArrayField,
+	"target":          stringField,
+	"explode":         boolField,
+	"props":           stringField,
+	"sortOrder":       stringField,
+	"sortBy":          stringArrayField,
+	"offset":          intField,
+	"limit":           intField,
+	"build":           stringField,
+	"recursive":       boolField,
+	"flat":            boolField,
+	"regexp":          boolField,
+	"includeDirs":     boolField,
+	"archiveEntries":  stringField,
+}
+
+var specRootFields = []string{"files", "include"}
+
+// The spec checks of a command.
+type commandRules struct {
+	targetRequired bool
+	// The command reads the patterns from the local file system rather than from Artifactory, so AQL can't be used.
+	fileSystem bool
+	// The file group fields the command ignores.
+	unsupported []string
+}
+
+var specCommands = map[string]commandRules{
+	"upload":                 {targetRequired: true, fileSystem: true, unsupported: []string{"aql", "build", "sortBy", "sortOrder", "offset", "limit", "archiveEntries"}},
+	"download":               {unsupported: []string{"regexp"}},
+	"copy":                   {targetRequired: true, unsupported: []string{"regexp", "explode"}},
+	"move":                   {targetRequired: true, unsupported: []string{"regexp", "explode"}},
+	"delete":                 {unsupported: []string{"target", "regexp", "explode", "flat"}},
+	"search":                 {unsupported: []string{"target", "regexp", "explode", "flat"}},
+	"set-props":              {unsupported: []string{"target", "regexp", "explode", "flat"}},
+	"delete-props":           {unsupported: []string{"target", "regexp", "explode", "flat"}},
+	"build-add-dependencies": {fileSystem: true, unsupported: []string{"aql", "target", "explode", "flat", "props", "build", "sortBy", "sortOrder", "offset", "limit", "archiveEntries"}},
+}
+
+func GetSpecCommands() []string {
+	var commands []string
+	for command := range specCommands {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+var aqlComparisonOperators = []string{"$eq", "$ne", "$gt", "$gte", "$lt", "$lte", "$match", "$nmatch"}
+var aqlLogicalOperators = []string{"$and", "$or", "$msp"}
+
+// Validates a spec file, in JSON or YAML format, strictly. Unlike reading the spec, unknown fields and values of the wrong type are errors.
+// If the command is set, the spec is also checked for use with the command.
+// The variables are resolved as when reading the spec, and the unresolved variables are errors. The values with variables aren't checked further.
+// The included spec files are validated as well.
+// Returns the issues found, and an error if the spec file couldn't be validated.
+func ValidateSpecFile(specFilePath, command string, specVars map[string]string) ([]ValidationError, error) {
+	if command != "" {
+		if _, exists := specCommands[command]; !exists {
+			return nil, errorutils.CheckError(errors.New("Unsupported command '" + command + "'. The supported commands are: " + strings.Join(GetSpecCommands(), ", ") + "."))
+		}
+	}
+	validator := &specValidator{command: command, specVars: specVars, validating: map[string]bool{}}
+	err := validator.validateFile(specFilePath)
+	return validator.errors, err
+}
+
+type specValidator struct {
+	command    string
+	specVars   map[string]string
+	validating map[string]bool
+	// The spec file being validated, which may be included by another spec.
+	file   string
+	isYaml bool
+	errors []ValidationError
+}
+
+func (validator *specValidator) addError(path string, nodePosition position, message string) {
+	validator.errors = append(validator.errors, ValidationError{File: validator.file, Line: nodePosition.line, Column: nodePosition.column, Path: path, Message: message})
+}
+
+func (validator *specValidator) validateFile(specFilePath string) error {
+	absolutePath, err := filepath.Abs(specFilePath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if validator.validating[absolutePath] {
+		validator.addError("include", position{}, "The spec includes itself.")
+		return nil
+	}
+	content, err := fileutils.ReadFile(specFilePath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	previousFile, previousIsYaml := validator.file, validator.isYaml
+	validator.file, validator.isYaml = specFilePath, isYamlSpec(specFilePath)
+	validator.validating[absolutePath] = true
+	defer func() {
+		validator.file, validator.isYaml = previousFile, previousIsYaml
+		delete(validator.validating, absolutePath)
+	}()
+
+	validator.validateVariables(content)
+	var root *specNode
+	if validator.isYaml {
+		root, err = parseYamlSpecNode(content)
+	} else {
+		root, err = parseJsonSpecNode(content)
+	}
+	if err != nil {
+		if specSyntaxError, ok := err.(*syntaxError); ok {
+			validator.addError("", specSyntaxError.position, "Invalid syntax: "+specSyntaxError.message)
+			return nil
+		}
+		return err
+	}
+	return validator.validateRoot(root, specFilePath)
+}
+
+func (validator *specValidator) validateVariables(content []byte) {
+	for _, indexes := range specVarRegexp.FindAllSubmatchIndex(content, -1) {
+		reference := content[indexes[0]:indexes[1]]
+		if strings.HasPrefix(string(reference), "$$") {
+			continue
+		}
+		key := string(content[indexes[2]:indexes[3]])
+		_, isSpecVar := validator.specVars[key]
+		_, isEnvVar := os.LookupEnv(key)
+		hasDefault := indexes[4] >= 0
+		if !isSpecVar && !isEnvVar && !hasDefault {
+			validator.addError("", offsetToPosition(content, indexes[0]), "Unresolved variable "+key+". Set it using the --spec-vars option or as an environment variable, or give it a default value as ${"+key+":-default}.")
+		}
+	}
+}
+
+func (validator *specValidator) validateRoot(root *specNode, specFilePath string) error {
+	if root.kind != objectNode {
+		validator.addError("", root.position, "The spec must be an object with a files list.")
+		return nil
+	}
+	var files *specNode
+	for _, member := range root.members {
+		switch strings.ToLower(member.key) {
+		case "files":
+			files = member.value
+			validator.validateFiles(files)
+		case "include":
+			if err := validator.validateIncludes(member.value, specFilePath); err != nil {
+				return err
+			}
+		default:
+			validator.addError(member.key, member.keyPosition, unknownFieldMessage(member.key, specRootFields))
+		}
+	}
+	if files == nil && !hasMember(root, "include") {
+		validator.addError("", root.position, "The spec must include at least one file group.")
+	}
+	return nil
+}
+
+func hasMember(node *specNode, key string) bool {
+	for _, member := range node.members {
+		if strings.ToLower(member.key) == strings.ToLower(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (validator *specValidator) validateIncludes(includes *specNode, specFilePath string) error {
+	if includes.kind != arrayNode {
+		validator.addError("include", includes.position, "Must be a list of spec file paths.")
+		return nil
+	}
+	for i, include := range includes.items {
+		path := "include[" + strconv.Itoa(i) + "]"
+		includePath, ok := include.stringValue()
+		if !ok {
+			validator.addError(path, include.position, "Must be a spec file path.")
+			continue
+		}
+		if hasVariable(includePath) {
+			continue
+		}
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(specFilePath), includePath)
+		}
+		exists, err := fileutils.IsFileExists(includePath, false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			validator.addError(path, include.position, "The included spec "+includePath+" doesn't exist.")
+			continue
+		}
+		if err := validator.validateFile(includePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (validator *specValidator) validateFiles(files *specNode) {
+	if files.kind != arrayNode {
+		validator.addError("files", files.position, "Must be a list of file groups.")
+		return
+	}
+	if len(files.items) == 0 {
+		validator.addError("files", files.position, "The spec must include at least one file group.")
+	}
+	for i, file := range files.items {
+		validator.validateFileGroup("files["+strconv.Itoa(i)+"]", file)
+	}
+}
+
+func (validator *specValidator) validateFileGroup(path string, file *specNode) {
+	if file.kind != objectNode {
+		validator.addError(path, file.position, "A file group must be an object.")
+		return
+	}
+	fieldNames := getSortedFieldNames()
+	fields := make(map[string]*specMember)
+	for _, member := range file.members {
+		fieldName := findFieldName(member.key, fieldNames)
+		if fieldName == "" {
+			validator.addError(path+"."+member.key, member.keyPosition, unknownFieldMessage(member.key, fieldNames))
+			continue
+		}
+		if _, exists := fields[fieldName]; exists {
+			validator.addError(path+"."+member.key, member.keyPosition, "The field is set more than once.")
+			continue
+		}
+		fields[fieldName] = member
+		validator.validateField(path+"."+member.key, specFileFields[fieldName], member.value)
+	}
+
+	_, isPattern := fields["pattern"]
+	_, isAql := fields["aql"]
+	switch {
+	case !isPattern && !isAql:
+		validator.addError(path, file.position, "A file group must include either the aql or pattern fields.")
+	case isPattern && isAql:
+		validator.addError(path, fields["aql"].keyPosition, "A file group cannot include both the aql and pattern fields.")
+	}
+	for _, conflict := range [][]string{{"aql", "excludePatterns"}, {"build", "offset"}, {"build", "limit"}} {
+		if fields[conflict[0]] != nil && fields[conflict[1]] != nil {
+			validator.addError(path, fields[conflict[1]].keyPosition, "A file group cannot include both the "+conflict[0]+" and "+conflict[1]+" fields.")
+		}
+	}
+	if sortOrder := fields["sortOrder"]; sortOrder != nil {
+		if fields["sortBy"] == nil {
+			validator.addError(path, sortOrder.keyPosition, "A file group cannot include sortOrder if sortBy is not included.")
+		}
+		if value, ok := sortOrder.value.stringValue(); ok && !hasVariable(value) && value != "asc" && value != "desc" {
+			validator.addError(path+"."+sortOrder.key, sortOrder.value.position, "The value of sortOrder can only be 'asc' or 'desc'.")
+		}
+	}
+	validator.validateRegexps(path, fields)
+	validator.validateCommand(path, file, fields)
+}
+
+func getSortedFieldNames() []string {
+	var fieldNames []string
+	for fieldName := range specFileFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	return fieldNames
+}
+
+func findFieldName(key string, fieldNames []string) string {
+	for _, fieldName := range fieldNames {
+		if strings.ToLower(key) == strings.ToLower(fieldName) {
+			return fieldName
+		}
+	}
+	return ""
+}
+
+func unknownFieldMessage(key string, fieldNames []string) string {
+	message := "Unknown field."
+	bestDistance := 3
+	suggestion := ""
+	for _, fieldName := range fieldNames {
+		if distance := levenshteinDistance(strings.ToLower(key), strings.ToLower(fieldName)); distance < bestDistance {
+			bestDistance, suggestion = distance, fieldName
+		}
+	}
+	if suggestion != "" {
+		message += " Did you mean '" + suggestion + "'?"
+	}
+	return message
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
+
+func (validator *specValidator) validateField(path string, fieldType fieldType, value *specNode) {
+	switch fieldType {
+	case stringField:
+		if _, ok := value.stringValue(); !ok {
+			validator.addError(path, value.position, "Must be a string.")
+		}
+	case boolField:
+		// JSON specs hold the booleans as strings, while YAML booleans are converted to strings when the spec is read.
+		if _, ok := value.value.(bool); ok && validator.isYaml {
+			return
+		}
+		stringValue, ok := value.stringValue()
+		if !ok {
+			validator.addError(path, value.position, "Must be a string holding true or false, such as \"true\".")
+			return
+		}
+		if _, err := strconv.ParseBool(stringValue); err != nil && !hasVariable(stringValue) {
+			validator.addError(path, value.position, "Must be true or false.")
+		}
+	case intField:
+		if !isNonNegativeInt(value) {
+			validator.addError(path, value.position, "Must be a non-negative integer.")
+		}
+	case stringArrayField:
+		if value.kind != arrayNode {
+			validator.addError(path, value.position, "Must be a list of strings.")
+			return
+		}
+		for i, item := range value.items {
+			if _, ok := item.stringValue(); !ok {
+				validator.addError(path+"["+strconv.Itoa(i)+"]", item.position, "Must be a string.")
+			}
+		}
+	case aqlField:
+		validator.validateAql(path, value)
+	}
+}
+
+func isNonNegativeInt(value *specNode) bool {
+	if value.kind != scalarNode {
+		return false
+	}
+	switch number := value.value.(type) {
+	case json.Number:
+		intValue, err := strconv.ParseInt(string(number), 10, 64)
+		return err == nil && intValue >= 0
+	case int:
+		return number >= 0
+	}
+	return false
+}
+
+// The AQL of a spec is an object holding the criteria of an items.find query.
+func (validator *specValidator) validateAql(path string, aql *specNode) {
+	if aql.kind != objectNode || len(aql.members) != 1 || aql.members[0].key != "items.find" {
+		validator.addError(path, aql.position, "Must be an object with a single items.find field.")
+		return
+	}
+	validator.validateAqlCriteria(path+".items.find", aql.members[0].value)
+}
+
+func (validator *specValidator) validateAqlCriteria(path string, criteria *specNode) {
+	if criteria.kind != objectNode {
+		validator.addError(path, criteria.position, "AQL criteria must be an object.")
+		return
+	}
+	for _, member := range criteria.members {
+		memberPath := path + "." + member.key
+		switch {
+		case contains(aqlLogicalOperators, member.key):
+			if member.value.kind != arrayNode {
+				validator.addError(memberPath, member.value.position, "The "+member.key+" operator must hold a list of criteria.")
+				continue
+			}
+			for i, item := range member.value.items {
+				validator.validateAqlCriteria(memberPath+"["+strconv.Itoa(i)+"]", item)
+			}
+		case strings.HasPrefix(member.key, "$"):
+			validator.addError(memberPath, member.keyPosition, "Unknown AQL operator "+member.key+".")
+		default:
+			validator.validateAqlFieldCriteria(memberPath, member.value)
+		}
+	}
+}
+
+// A field is compared either to a value, or using comparison operators.
+func (validator *specValidator) validateAqlFieldCriteria(path string, value *specNode) {
+	switch value.kind {
+	case arrayNode:
+		validator.addError(path, value.position, "An AQL field must be compared to a value or an object of comparison operators.")
+	case objectNode:
+		for _, member := range value.members {
+			if !contains(aqlComparisonOperators, member.key) {
+				validator.addError(path+"."+member.key, member.keyPosition, "Unknown AQL comparison operator "+member.key+". The supported operators are: "+strings.Join(aqlComparisonOperators, ", ")+".")
+			} else if member.value.kind != scalarNode {
+				validator.addError(path+"."+member.key, member.value.position, "An AQL comparison operator must hold a value.")
+			}
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// When regexp is true, the pattern and exclude patterns are regular expressions.
+func (validator *specValidator) validateRegexps(path string, fields map[string]*specMember) {
+	regexpField := fields["regexp"]
+	if regexpField == nil || !isTrue(regexpField.value) {
+		return
+	}
+	var patterns []*specMember
+	if fields["pattern"] != nil {
+		patterns = append(patterns, fields["pattern"])
+	}
+	if excludePatterns := fields["excludePatterns"]; excludePatterns != nil {
+		for _, item := range excludePatterns.value.items {
+			patterns = append(patterns, &specMember{key: excludePatterns.key, value: item})
+		}
+	}
+	for _, pattern := range patterns {
+		value, ok := pattern.value.stringValue()
+		if !ok || hasVariable(value) {
+			continue
+		}
+		if _, err := regexp.Compile(value); err != nil {
+			validator.addError(path+"."+pattern.key, pattern.value.position, "Invalid regular expression: "+err.Error())
+		}
+	}
+}
+
+func isTrue(value *specNode) bool {
+	if boolValue, ok := value.value.(bool); ok {
+		return boolValue
+	}
+	stringValue, _ := value.stringValue()
+	boolValue, _ := strconv.ParseBool(stringValue)
+	return boolValue
+}
+
+func (validator *specValidator) validateCommand(path string, file *specNode, fields map[string]*specMember) {
+	if validator.command == "" {
+		return
+	}
+	rules := specCommands[validator.command]
+	if rules.targetRequired && fields["target"] == nil {
+		validator.addError(path, file.position, "The "+validator.command+" command requires a target.")
+	}
+	if rules.fileSystem && fields["aql"] != nil {
+		validator.addError(path+"."+fields["aql"].key, fields["aql"].keyPosition, "The "+validator.command+" command reads the files from the file system, so it cannot use aql.")
+	}
+	for _, fieldName := range rules.unsupported {
+		if fieldName == "aql" && rules.fileSystem {
+			continue
+		}
+		if field := fields[fieldName]; field != nil {
+			validator.addError(path+"."+field.key, field.keyPosition, "The "+validator.command+" command doesn't support the "+fieldName+" field.")
+		}
+	}
+}
+
+func hasVariable(value string) bool {
+	return specVarRegexp.MatchString(value)
+}
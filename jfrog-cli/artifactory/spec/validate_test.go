@@ -0,0 +1,182 @@
+package spec
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidateSpecFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec_validate_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		command  string
+		expected []ValidationError
+	}{
+		{"valid", "valid.json", `{"files": [{"pattern": "repo/*.zip", "target": "out/", "flat": "true", "sortBy": ["name"], "sortOrder": "asc"}]}`, "download", nil},
+		{"unknownField", "unknown.json", "{\n  \"files\": [\n    {\n      \"Pattren\": \"repo/*.zip\"\n    }\n  ]\n}", "", []ValidationError{
+			{Line: 4, Column: 7, Path: "files[0].Pattren", Message: "Unknown field. Did you mean 'pattern'?"},
+			{Line: 3, Column: 5, Path: "files[0]", Message: "A file group must include either the aql or pattern fields."},
+		}},
+		{"wrongTypes", "types.json", `{"files": [{"pattern": "a/*", "flat": true, "limit": -1, "sortOrder": "up"}]}`, "", []ValidationError{
+			{Line: 1, Column: 39, Path: "files[0].flat", Message: "Must be a string holding true or false, such as \"true\"."},
+			{Line: 1, Column: 54, Path: "files[0].limit", Message: "Must be a non-negative integer."},
+			{Line: 1, Column: 58, Path: "files[0]", Message: "A file group cannot include sortOrder if sortBy is not included."},
+			{Line: 1, Column: 71, Path: "files[0].sortOrder", Message: "The value of sortOrder can only be 'asc' or 'desc'."},
+		}},
+		{"targetRequired", "copy.json", `{"files": [{"pattern": "a/*", "explode": "true"}]}`, "copy", []ValidationError{
+			{Line: 1, Column: 12, Path: "files[0]", Message: "The copy command requires a target."},
+			{Line: 1, Column: 31, Path: "files[0].explode", Message: "The copy command doesn't support the explode field."},
+		}},
+		{"uploadAql", "upload.json", `{"files": [{"aql": {"items.find": {"repo": "a"}}, "target": "b/"}]}`, "upload", []ValidationError{
+			{Line: 1, Column: 13, Path: "files[0].aql", Message: "The upload command reads the files from the file system, so it cannot use aql."},
+		}},
+		{"invalidRegexp", "regexp.json", `{"files": [{"pattern": "a/(.*", "target": "b/", "regexp": "true"}]}`, "upload", []ValidationError{
+			{Line: 1, Column: 24, Path: "files[0].pattern", Message: "Invalid regular expression: error parsing regexp: missing closing ): `a/(.*`"},
+		}},
+		{"invalidAql", "aql.json", `{"files": [{"aql": {"items.find": {"$and": [{"name": {"$like": "*.zip"}}], "$not": []}}}]}`, "", []ValidationError{
+			{Line: 1, Column: 55, Path: "files[0].aql.items.find.$and[0].name.$like", Message: "Unknown AQL comparison operator $like. The supported operators are: $eq, $ne, $gt, $gte, $lt, $lte, $match, $nmatch."},
+			{Line: 1, Column: 76, Path: "files[0].aql.items.find.$not", Message: "Unknown AQL operator $not."},
+		}},
+		{"unresolvedVariable", "vars.json", "{\"files\": [{\"pattern\": \"${repo}/*\"}],\n \"Files\": [{\"pattern\": \"${missing}/${repo:-a}\"}]}", "", []ValidationError{
+			{Line: 2, Column: 25, Message: "Unresolved variable missing. Set it using the --spec-vars option or as an environment variable, or give it a default value as ${missing:-default}."},
+		}},
+		{"syntaxError", "syntax.json", "{\"files\": [\n  {\"pattern\": \"a\",}]}", "", []ValidationError{
+			{Line: 2, Column: 19, Message: "Invalid syntax: invalid character ',' looking for beginning of value"},
+		}},
+		{"yaml", "spec.yaml", "files:\n  - pattern: a/*\n    flat: true\n    target: b/\n    explode: maybe\n", "upload", []ValidationError{
+			{Line: 5, Column: 14, Path: "files[0].explode", Message: "Must be true or false."},
+		}},
+		{"yamlSyntaxError", "syntax.yaml", "files:\n\t- pattern: a/*\n", "", []ValidationError{
+			{Line: 2, Message: "Invalid syntax: found character that cannot start any token"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specPath := filepath.Join(dir, test.fileName)
+			writeSpecFile(t, specPath, test.content)
+			validationErrors, err := ValidateSpecFile(specPath, test.command, map[string]string{"repo": "libs"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range test.expected {
+				test.expected[i].File = specPath
+			}
+			if !reflect.DeepEqual(validationErrors, test.expected) {
+				t.Errorf("Expected:\n%v\nGot:\n%v", test.expected, validationErrors)
+			}
+		})
+	}
+
+	if _, err := ValidateSpecFile(filepath.Join(dir, "valid.json"), "unknown", nil); err == nil {
+		t.Error("Expected an error for an unsupported command.")
+	}
+}
+
+func TestValidateSpecFileIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec_validate_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeSpecFile(t, filepath.Join(dir, "common", "common.json"), `{"files": [{"pattern": "a/*", "flatt": "true"}]}`)
+	writeSpecFile(t, filepath.Join(dir, "spec.json"), `{"include": ["common/common.json", "missing.json"]}`)
+
+	validationErrors, err := ValidateSpecFile(filepath.Join(dir, "spec.json"), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validationErrors) != 2 {
+		t.Fatal("Expected 2 errors, got:", validationErrors)
+	}
+	if validationErrors[0].File != filepath.Join(dir, "common", "common.json") || validationErrors[0].Message != "Unknown field. Did you mean 'flat'?" {
+		t.Error("Unexpected error of the included spec:", validationErrors[0])
+	}
+	if validationErrors[1].Path != "include[1]" || !strings.Contains(validationErrors[1].Message, "doesn't exist") {
+		t.Error("Unexpected error of the missing spec:", validationErrors[1])
+	}
+}
+
+// The schema, the validated fields and the File struct describe the same fields, with the same types.
+func TestSchemaFields(t *testing.T) {
+	type schemaProperty struct {
+		Type string `json:"type"`
+		Ref  string `json:"$ref"`
+	}
+	var schema struct {
+		Properties  map[string]schemaProperty `json:"properties"`
+		Definitions struct {
+			File struct {
+				Properties map[string]schemaProperty `json:"properties"`
+			} `json:"file"`
+			Boolean struct {
+				Pattern string `json:"pattern"`
+			} `json:"boolean"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal([]byte(Schema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	var schemaRootFields []string
+	for fieldName := range schema.Properties {
+		schemaRootFields = append(schemaRootFields, fieldName)
+	}
+	sort.Strings(schemaRootFields)
+	if !reflect.DeepEqual(schemaRootFields, specRootFields) {
+		t.Errorf("The schema root fields %v differ from the validated root fields %v.", schemaRootFields, specRootFields)
+	}
+
+	var schemaFields []string
+	for fieldName := range schema.Definitions.File.Properties {
+		schemaFields = append(schemaFields, fieldName)
+	}
+	sort.Strings(schemaFields)
+	if !reflect.DeepEqual(schemaFields, getSortedFieldNames()) {
+		t.Errorf("The schema fields %v differ from the validated fields %v.", schemaFields, getSortedFieldNames())
+	}
+	schemaFieldTypes := map[fieldType]schemaProperty{
+		stringField:      {Type: "string"},
+		boolField:        {Ref: "#/definitions/boolean"},
+		intField:         {Type: "integer"},
+		stringArrayField: {Type: "array"},
+		aqlField:         {Type: "object"},
+	}
+	for fieldName, property := range schema.Definitions.File.Properties {
+		if validatedType, exists := specFileFields[fieldName]; exists && schemaFieldTypes[validatedType] != property {
+			t.Errorf("The schema type %v of the %s field differs from its validated type %v.", property, fieldName, schemaFieldTypes[validatedType])
+		}
+	}
+
+	// The schema booleans match the values the validator accepts, which are the values the spec booleans are parsed from.
+	booleanPattern, err := regexp.Compile(schema.Definitions.Boolean.Pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"true", "false", "TRUE", "False", "t", "F", "1", "0", "${flat}", "${flat:-true}", "yes", "tRUE", "2", ""} {
+		validator := &specValidator{}
+		validator.validateField("flat", boolField, &specNode{kind: scalarNode, value: value})
+		if schemaValid, validated := booleanPattern.MatchString(value), len(validator.errors) == 0; schemaValid != validated {
+			t.Errorf("The schema boolean pattern matches '%s': %t, while the validator accepts it: %t.", value, schemaValid, validated)
+		}
+	}
+
+	fileType := reflect.TypeOf(File{})
+	for i := 0; i < fileType.NumField(); i++ {
+		if findFieldName(fileType.Field(i).Name, getSortedFieldNames()) == "" {
+			t.Error("The File field", fileType.Field(i).Name, "isn't validated.")
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"path"
+)
+
+// The build-info collected and published by the CLI.
+// Extends the build-info of the client with the details it doesn't include: the path, type and SHA256 of the artifacts,
+// the path of the dependencies, the principal, the VCS list, the CI details and the issues.
+// The client types are embedded, so that the CLI types add only these details, and can be dropped once the client includes them.
+type BuildInfo struct {
+	buildinfo.BuildInfo
+	// Overrides the modules of the client build-info.
+	Modules []Module `json:"modules,omitempty"`
+	// The user who triggered the build.
+	Principal string  `json:"principal,omitempty"`
+	VcsList   []Vcs   `json:"vcs,omitempty"`
+	Ci        *Ci     `json:"ci,omitempty"`
+	Issues    *Issues `json:"issues,omitempty"`
+}
+
+type Vcs struct {
+	Url      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Author   string `json:"author,omitempty"`
+	// True if the working tree had changes which weren't committed.
+	Dirty      bool           `json:"dirty,omitempty"`
+	Submodules []VcsSubmodule `json:"submodules,omitempty"`
+	// The files which changed since the revision of the previous build.
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+}
+
+type VcsSubmodule struct {
+	Path     string `json:"path,omitempty"`
+	Url      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+// The details of the CI job which ran the build.
+type Ci struct {
+	Provider string `json:"provider,omitempty"`
+	JobName  string `json:"jobName,omitempty"`
+	JobId    string `json:"jobId,omitempty"`
+}
+
+// The details collected from the CI provider which runs the build.
+type CiDetails struct {
+	Ci        Ci
+	BuildUrl  string
+	Principal string
+	Vcs       Vcs
+}
+
+// The issues of the issue tracker, which the commits included in the build refer to.
+type Issues struct {
+	Tracker *Tracker `json:"tracker,omitempty"`
+	// If true, the issues of the previous builds, since the last build with the aggregation status, are aggregated to this build.
+	AggregateBuildIssues   bool            `json:"aggregateBuildIssues,omitempty"`
+	AggregationBuildStatus string          `json:"aggregationBuildStatus,omitempty"`
+	AffectedIssues         []AffectedIssue `json:"affectedIssues,omitempty"`
+}
+
+type Tracker struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type AffectedIssue struct {
+	Key     string `json:"key,omitempty"`
+	Url     string `json:"url,omitempty"`
+	Summary string `json:"summary,omitempty"`
+	// True if the issue was aggregated from a previous build.
+	Aggregated bool `json:"aggregated,omitempty"`
+}
+
+type Module struct {
+	buildinfo.Module
+	// Overrides the artifacts and dependencies of the client module.
+	Artifacts    []Artifact   `json:"artifacts,omitempty"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+type Artifact struct {
+	buildinfo.Artifact
+	Type string `json:"type,omitempty"`
+	// The path of the artifact in Artifactory, including the repository.
+	Path   string `json:"path,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+// Returns the path which identifies the artifact in its module, since artifacts with the same name may be deployed to different paths.
+// The path of artifacts which were published by other tools may not include their name, and artifacts without a path are identified by their name.
+func (artifact *Artifact) GetFullPath() string {
+	switch {
+	case artifact.Path == "":
+		return artifact.Name
+	case path.Base(artifact.Path) == artifact.Name:
+		return artifact.Path
+	}
+	return path.Join(artifact.Path, artifact.Name)
+}
+
+type Dependency struct {
+	buildinfo.Dependency
+	// The path of the dependency in Artifactory, including the repository.
+	Path string `json:"path,omitempty"`
+}
+
+type Partials []*Partial
+
+type Partial struct {
+	buildinfo.Partial
+	// Overrides the artifacts and dependencies of the client partial.
+	Artifacts    []Artifact   `json:"Artifacts,omitempty"`
+	Dependencies []Dependency `json:"Dependencies,omitempty"`
+	CiDetails    *CiDetails   `json:"CiDetails,omitempty"`
+	// The detailed VCS collected by build-add-git, in addition to the URL and revision of the client partial.
+	VcsDetails *Vcs `json:"VcsDetails,omitempty"`
+	// The issues collected by build-add-issues.
+	Issues *Issues `json:"Issues,omitempty"`
+}
+
+func (partials Partials) Len() int {
+	return len(partials)
+}
+
+func (partials Partials) Less(i, j int) bool {
+	return partials[i].Timestamp < partials[j].Timestamp
+}
+
+func (partials Partials) Swap(i, j int) {
+	partials[i], partials[j] = partials[j], partials[i]
+}
+
+func NewBuildInfo() *BuildInfo {
+	return &BuildInfo{BuildInfo: *buildinfo.New(), Modules: make([]Module, 0)}
+}
+
+// Converts a build-info created by the client, which has no extended details.
+func ConvertBuildInfo(clientBuildInfo *buildinfo.BuildInfo) *BuildInfo {
+	buildInfo := &BuildInfo{BuildInfo: *clientBuildInfo, Modules: make([]Module, 0)}
+	buildInfo.BuildInfo.Modules = nil
+	for _, module := range clientBuildInfo.Modules {
+		artifacts := NewArtifacts(module.Artifacts)
+		dependencies := NewDependencies(module.Dependencies)
+		module.Artifacts, module.Dependencies = nil, nil
+		buildInfo.Modules = append(buildInfo.Modules, Module{Module: module, Artifacts: artifacts, Dependencies: dependencies})
+	}
+	return buildInfo
+}
+
+// Converts artifacts of the client build-info, which have no extended details.
+func NewArtifacts(artifacts []buildinfo.Artifact) []Artifact {
+	var extendedArtifacts []Artifact
+	for _, artifact := range artifacts {
+		extendedArtifacts = append(extendedArtifacts, Artifact{Artifact: artifact})
+	}
+	return extendedArtifacts
+}
+
+// Converts dependencies of the client build-info, which have no extended details.
+func NewDependencies(dependencies []buildinfo.Dependency) []Dependency {
+	var extendedDependencies []Dependency
+	for _, dependency := range dependencies {
+		extendedDependencies = append(extendedDependencies, Dependency{Dependency: dependency})
+	}
+	return extendedDependencies
+}
+
+// Append the modules of the received build info to this build info.
+// If the two build info instances contain modules with identical names, these modules are merged.
+// When merging the modules, the artifacts and dependencies remain unique according to their paths and checksums.
+func (targetBuildInfo *BuildInfo) Append(buildInfo *BuildInfo) {
+	for _, newModule := range buildInfo.Modules {
+		exists := false
+		for i := range targetBuildInfo.Modules {
+			if newModule.Id == targetBuildInfo.Modules[i].Id {
+				mergeModules(&newModule, &targetBuildInfo.Modules[i])
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			targetBuildInfo.Modules = append(targetBuildInfo.Modules, newModule)
+		}
+	}
+}
+
+// Merge the first module into the second module.
+func mergeModules(merge *Module, into *Module) {
+	for _, mergeArtifact := range merge.Artifacts {
+		exists := false
+		for _, artifact := range into.Artifacts {
+			if isSameArtifact(mergeArtifact, artifact) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			into.Artifacts = append(into.Artifacts, mergeArtifact)
+		}
+	}
+	for _, mergeDependency := range merge.Dependencies {
+		exists := false
+		for _, dependency := range into.Dependencies {
+			if isSameDependency(mergeDependency, dependency) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			into.Dependencies = append(into.Dependencies, mergeDependency)
+		}
+	}
+}
+
+// Different files may have the same checksum, and the checksum may be empty, so the artifacts are also identified by their paths.
+func isSameArtifact(artifactA, artifactB Artifact) bool {
+	return artifactA.GetFullPath() == artifactB.GetFullPath() && getSha1(artifactA.Checksum) == getSha1(artifactB.Checksum)
+}
+
+func isSameDependency(dependencyA, dependencyB Dependency) bool {
+	return dependencyA.Id == dependencyB.Id && dependencyA.Path == dependencyB.Path && getSha1(dependencyA.Checksum) == getSha1(dependencyB.Checksum)
+}
+
+func getSha1(checksum *buildinfo.Checksum) string {
+	if checksum == nil {
+		return ""
+	}
+	return checksum.Sha1
+}
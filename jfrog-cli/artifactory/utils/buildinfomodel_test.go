@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"testing"
+)
+
+func TestAppendBuildInfo(t *testing.T) {
+	newArtifact := func(path, sha1 string) Artifact {
+		artifact := Artifact{Artifact: buildinfo.Artifact{Name: "app.jar"}, Path: path}
+		if sha1 != "" {
+			artifact.Checksum = &buildinfo.Checksum{Sha1: sha1}
+		}
+		return artifact
+	}
+	buildInfo := NewBuildInfo()
+	buildInfo.Modules = []Module{{Module: buildinfo.Module{Id: "app"}, Artifacts: []Artifact{newArtifact("repo/a/app.jar", "1"), newArtifact("repo/dry/app.jar", "")}}}
+	partial := NewBuildInfo()
+	partial.Modules = []Module{{
+		Module: buildinfo.Module{Id: "app"},
+		Artifacts: []Artifact{
+			// The same artifact.
+			newArtifact("repo/a/app.jar", "1"),
+			// Another file with the same content.
+			newArtifact("repo/b/app.jar", "1"),
+			// Another file without a checksum.
+			newArtifact("repo/other/app.jar", ""),
+		},
+	}}
+	buildInfo.Append(partial)
+
+	expectedPaths := []string{"repo/a/app.jar", "repo/dry/app.jar", "repo/b/app.jar", "repo/other/app.jar"}
+	artifacts := buildInfo.Modules[0].Artifacts
+	if len(artifacts) != len(expectedPaths) {
+		t.Fatal("Unexpected merged artifacts:", artifacts)
+	}
+	for i, expectedPath := range expectedPaths {
+		if artifacts[i].GetFullPath() != expectedPath {
+			t.Error("Expected the artifact", expectedPath, "got:", artifacts[i].GetFullPath())
+		}
+	}
+}
+
+func TestArtifactFullPath(t *testing.T) {
+	tests := []struct {
+		artifact Artifact
+		expected string
+	}{
+		{Artifact{Artifact: buildinfo.Artifact{Name: "app.jar"}}, "app.jar"},
+		{Artifact{Artifact: buildinfo.Artifact{Name: "app.jar"}, Path: "repo/a/app.jar"}, "repo/a/app.jar"},
+		{Artifact{Artifact: buildinfo.Artifact{Name: "app.jar"}, Path: "org/app/1.0"}, "org/app/1.0/app.jar"},
+	}
+	for _, test := range tests {
+		if fullPath := test.artifact.GetFullPath(); fullPath != test.expected {
+			t.Error("Expected", test.expected, "got:", fullPath)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/ioutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/spf13/viper"
 	"io/ioutil"
@@ -145,6 +146,65 @@ func ReadConfigFile(configPath string, configType ConfigType) (*viper.Viper, err
 	return config, nil
 }
 
+// Reads the build config file, and sets the repositories of the build tool in the project configuration to it.
+// If the path of the build config file is empty, the build config is created from the project configuration alone.
+func ReadBuildConfig(configPath string, buildType BuildType, projectConfig *config.ProjectConfig) (*viper.Viper, error) {
+	if projectConfig == nil {
+		projectConfig = new(config.ProjectConfig)
+	}
+	var vConfig *viper.Viper
+	if configPath == "" {
+		if projectConfig.GetRepository(buildType.String(), false) == "" && projectConfig.GetRepository(buildType.String(), true) == "" {
+			return nil, errorutils.CheckError(errors.New("The config file path is required, since the project configuration doesn't set the " + buildType.String() + " repositories."))
+		}
+		vConfig = viper.New()
+		vConfig.Set("type", buildType.String())
+	} else {
+		var err error
+		if vConfig, err = ReadConfigFile(configPath, YAML); err != nil {
+			return nil, err
+		}
+	}
+	return vConfig, setProjectRepositoriesToConfig(vConfig, buildType, projectConfig)
+}
+
+// Sets the resolution and deployment repositories of the project configuration, unless the build config sets its own.
+// The repositories are used with the server of the build config, or else with the server of the project configuration or the default server.
+func setProjectRepositoriesToConfig(vConfig *viper.Viper, buildType BuildType, projectConfig *config.ProjectConfig) error {
+	for _, deploy := range []bool{false, true} {
+		repository := projectConfig.GetRepository(buildType.String(), deploy)
+		prefix := RESOLVER_PREFIX
+		if deploy {
+			prefix = DEPLOYER_PREFIX
+		}
+		if repository == "" || vConfig.IsSet(prefix+REPO) || vConfig.IsSet(prefix+RELEASE_REPO) {
+			continue
+		}
+		if buildType == MAVEN {
+			vConfig.Set(prefix+RELEASE_REPO, repository)
+			vConfig.Set(prefix+SNAPSHOT_REPO, repository)
+		} else {
+			vConfig.Set(prefix+REPO, repository)
+		}
+		if vConfig.IsSet(prefix + SERVER_ID) {
+			continue
+		}
+		serverId := projectConfig.ServerId
+		if serverId == "" {
+			artDetails, err := config.GetArtifactorySpecificConfig("")
+			if err != nil {
+				return err
+			}
+			if artDetails.ServerId == "" {
+				return errorutils.CheckError(errors.New("The project configuration sets the " + buildType.String() + " repositories, but no Artifactory server is configured."))
+			}
+			serverId = artDetails.ServerId
+		}
+		vConfig.Set(prefix+SERVER_ID, serverId)
+	}
+	return nil
+}
+
 func CreateBuildInfoPropertiesFile(buildName, buildNumber string, config *viper.Viper, buildType BuildType) (string, error) {
 	if config.GetString("type") != buildType.String() {
 		return "", errorutils.CheckError(errors.New("Incompatible build config, expected: " + buildType.String() + " got: " + config.GetString("type")))
@@ -213,9 +273,13 @@ func setServerDetailsToConfig(contextPrefix string, vConfig *viper.Viper) error
 		return errorutils.CheckError(errors.New("Server ID " + serverId + " API key authentication is not supported"))
 	}
 
-	if artDetails.GetUser() != "" && artDetails.GetPassword() != "" {
-		vConfig.Set(contextPrefix+USERNAME, artDetails.GetUser())
-		vConfig.Set(contextPrefix+PASSWORD, artDetails.GetPassword())
+	user, password, err := artDetails.GetBasicAuthCredentials(lock.LockConfig)
+	if err != nil {
+		return err
+	}
+	if user != "" && password != "" {
+		vConfig.Set(contextPrefix+USERNAME, user)
+		vConfig.Set(contextPrefix+PASSWORD, password)
 	}
 	return nil
 }
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/spf13/viper"
+	"io/ioutil"
 	"os"
 	"testing"
 )
@@ -84,6 +86,45 @@ func TestCreateSimplePropertiesFile(t *testing.T) {
 	compareViperConfigs(t, actualConfig, expectedConfig, MAVEN)
 }
 
+func TestReadBuildConfigProjectRepositories(t *testing.T) {
+	projectConfig := &config.ProjectConfig{ServerId: "project-server", Repositories: map[string]config.ProjectRepository{
+		config.Maven: {Resolve: "maven-virtual", Deploy: "maven-local"},
+	}}
+
+	// The build config is created from the project configuration if the config file is omitted.
+	vConfig, err := ReadBuildConfig("", MAVEN, projectConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vConfig.GetString("type") != MAVEN.String() || vConfig.GetString(RESOLVER_PREFIX+RELEASE_REPO) != "maven-virtual" ||
+		vConfig.GetString(RESOLVER_PREFIX+SNAPSHOT_REPO) != "maven-virtual" || vConfig.GetString(DEPLOYER_PREFIX+RELEASE_REPO) != "maven-local" ||
+		vConfig.GetString(RESOLVER_PREFIX+SERVER_ID) != "project-server" || vConfig.GetString(DEPLOYER_PREFIX+SERVER_ID) != "project-server" {
+		t.Error("Unexpected build config:", vConfig.AllSettings())
+	}
+	if _, err = ReadBuildConfig("", GRADLE, projectConfig); err == nil {
+		t.Error("Expected an error for a missing config file, without the gradle repositories in the project configuration.")
+	}
+
+	// The repositories of the config file override the repositories of the project.
+	configFile, err := ioutil.TempFile("", "build_config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(configFile.Name())
+	if _, err = configFile.WriteString("type: maven\nresolver:\n  serverId: file-server\n  releaseRepo: file-releases\n  snapshotRepo: file-snapshots\n"); err != nil {
+		t.Fatal(err)
+	}
+	configFile.Close()
+	vConfig, err = ReadBuildConfig(configFile.Name(), MAVEN, projectConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vConfig.GetString(RESOLVER_PREFIX+RELEASE_REPO) != "file-releases" || vConfig.GetString(RESOLVER_PREFIX+SERVER_ID) != "file-server" ||
+		vConfig.GetString(DEPLOYER_PREFIX+RELEASE_REPO) != "maven-local" || vConfig.GetString(DEPLOYER_PREFIX+SERVER_ID) != "project-server" {
+		t.Error("Unexpected build config:", vConfig.AllSettings())
+	}
+}
+
 func TestGeneratedBuildInfoFile(t *testing.T) {
 	var yamlConfig = map[string]string{
 		RESOLVER_PREFIX + URL: "http://some.url.com",
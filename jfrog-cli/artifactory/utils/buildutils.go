@@ -5,6 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -19,19 +22,54 @@ import (
 )
 
 const BuildInfoDetails = "details"
-const BuildTempPath = "jfrog/builds/"
+
+// The directory under the builds directory, which contains the lock directory of each build.
+const buildsLocksDir = ".locks"
 
 func GetBuildDir(buildName, buildNumber string) (string, error) {
-	tempDir := os.TempDir()
-	encodedDirName := base64.StdEncoding.EncodeToString([]byte(buildName + "_" + buildNumber))
-	buildsDir := filepath.Join(tempDir, BuildTempPath, encodedDirName)
-	err := os.MkdirAll(buildsDir, 0777)
+	buildsDir, err := getBuildDirPath(buildName, buildNumber)
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(buildsDir, 0777)
 	if errorutils.CheckError(err) != nil {
 		return "", err
 	}
 	return buildsDir, nil
 }
 
+// Returns true if build-info data was collected locally for the build, and wasn't published or cleaned yet.
+func IsLocalBuildExists(buildName, buildNumber string) (bool, error) {
+	buildDir, err := getBuildDirPath(buildName, buildNumber)
+	if err != nil {
+		return false, err
+	}
+	return fileutils.IsDirExists(buildDir, false)
+}
+
+func getBuildDirPath(buildName, buildNumber string) (string, error) {
+	buildsDir, err := config.GetBuildsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(buildsDir, encodeBuildDirName(buildName, buildNumber)), nil
+}
+
+func encodeBuildDirName(buildName, buildNumber string) string {
+	return base64.StdEncoding.EncodeToString([]byte(buildName + "_" + buildNumber))
+}
+
+// Locks the build for the current process or goroutine, so that concurrent steps of the same build
+// don't corrupt or duplicate its data. The returned lock should be unlocked, also if an error is returned.
+// The locks are kept outside the build directory, so that removing the build doesn't remove its locks.
+func lockBuild(buildName, buildNumber string) (lock.Lock, error) {
+	buildsDir, err := config.GetBuildsDir()
+	if err != nil {
+		return lock.Lock{}, err
+	}
+	return lock.CreateLockInDir(filepath.Join(buildsDir, buildsLocksDir, encodeBuildDirName(buildName, buildNumber)))
+}
+
 func CreateBuildProperties(buildName, buildNumber string) (string, error) {
 	if buildName == "" || buildNumber == "" {
 		return "", nil
@@ -67,6 +105,11 @@ func saveBuildData(action interface{}, buildName, buildNumber string) error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
 	dirPath, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
@@ -91,6 +134,11 @@ func SaveBuildInfo(buildName, buildNumber string, buildInfo *buildinfo.BuildInfo
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
 	dirPath, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
@@ -106,13 +154,29 @@ func SaveBuildInfo(buildName, buildNumber string, buildInfo *buildinfo.BuildInfo
 }
 
 func SaveBuildGeneralDetails(buildName, buildNumber string) error {
+	exists, err := IsLocalBuildExists(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	// A new build was started, which is a good time to remove the builds which were abandoned.
+	// The expired builds are removed before locking this build, since removing each of them requires its lock.
+	if !exists {
+		if err = RemoveExpiredBuilds(); err != nil {
+			log.Warn("Failed removing expired builds:", err.Error())
+		}
+	}
+	// The build is locked, so that concurrent steps of the build don't create the details more than once.
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	log.Debug("Saving build general details at: " + partialsBuildDir)
 	if err != nil {
 		return err
 	}
 	detailsFilePath := filepath.Join(partialsBuildDir, BuildInfoDetails)
-	var exists bool
 	exists, err = fileutils.IsFileExists(detailsFilePath, false)
 	if err != nil {
 		return err
@@ -120,8 +184,10 @@ func SaveBuildGeneralDetails(buildName, buildNumber string) error {
 	if exists {
 		return nil
 	}
-	meta := buildinfo.General{
-		Timestamp: time.Now(),
+	meta := buildGeneralDetails{
+		General:     buildinfo.General{Timestamp: time.Now()},
+		BuildName:   buildName,
+		BuildNumber: buildNumber,
 	}
 	b, err := json.Marshal(&meta)
 	if err != nil {
@@ -136,16 +202,21 @@ func SaveBuildGeneralDetails(buildName, buildNumber string) error {
 	return errorutils.CheckError(err)
 }
 
-type populatePartialBuildInfo func(partial *buildinfo.Partial)
+type populatePartialBuildInfo func(partial *Partial)
 
 func SavePartialBuildInfo(buildName, buildNumber string, populatePartialBuildInfoFunc populatePartialBuildInfo) error {
-	partialBuildInfo := new(buildinfo.Partial)
+	partialBuildInfo := new(Partial)
 	partialBuildInfo.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
 	populatePartialBuildInfoFunc(partialBuildInfo)
 	return saveBuildData(partialBuildInfo, buildName, buildNumber)
 }
 
-func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildInfo, error) {
+func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*BuildInfo, error) {
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return nil, err
+	}
 	buildDir, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
 		return nil, err
@@ -155,7 +226,7 @@ func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildIn
 		return nil, err
 	}
 
-	var generatedBuildsInfo []*buildinfo.BuildInfo
+	var generatedBuildsInfo []*BuildInfo
 	for _, buildFile := range buildFiles {
 		dir, err := fileutils.IsDirExists(buildFile, false)
 		if err != nil {
@@ -168,15 +239,20 @@ func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildIn
 		if err != nil {
 			return nil, err
 		}
-		buildInfo := new(buildinfo.BuildInfo)
+		buildInfo := new(BuildInfo)
 		json.Unmarshal(content, &buildInfo)
 		generatedBuildsInfo = append(generatedBuildsInfo, buildInfo)
 	}
 	return generatedBuildsInfo, nil
 }
 
-func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partials, error) {
-	var partials buildinfo.Partials
+func ReadPartialBuildInfoFiles(buildName, buildNumber string) (Partials, error) {
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	var partials Partials
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return nil, err
@@ -200,7 +276,7 @@ func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partial
 		if err != nil {
 			return nil, err
 		}
-		partial := new(buildinfo.Partial)
+		partial := new(Partial)
 		json.Unmarshal(content, &partial)
 		partials = append(partials, partial)
 	}
@@ -209,6 +285,11 @@ func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partial
 }
 
 func ReadBuildInfoGeneralDetails(buildName, buildNumber string) (*buildinfo.General, error) {
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return nil, err
+	}
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return nil, err
@@ -223,7 +304,29 @@ func ReadBuildInfoGeneralDetails(buildName, buildNumber string) (*buildinfo.Gene
 	return details, nil
 }
 
+// Adds the artifacts of the build-info modules to the detailed summary.
+func AddBuildArtifactsToSummary(detailedSummary *summary.Summary, buildInfo *BuildInfo) {
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			file := summary.File{Target: artifact.Name, Module: module.Id, Sha256: artifact.Sha256}
+			if artifact.Path != "" {
+				file.Target = artifact.Path
+			}
+			if artifact.Checksum != nil {
+				file.Sha1 = artifact.Sha1
+				file.Md5 = artifact.Md5
+			}
+			detailedSummary.AddFile(file)
+		}
+	}
+}
+
 func RemoveBuildDir(buildName, buildNumber string) error {
+	lockFile, err := lockBuild(buildName, buildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
 	tempDirPath, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
@@ -238,6 +341,14 @@ func RemoveBuildDir(buildName, buildNumber string) error {
 	return nil
 }
 
+// The general details of a build collected locally.
+// The build name and number are kept, since they cannot be decoded from the build directory name unambiguously.
+type buildGeneralDetails struct {
+	buildinfo.General
+	BuildName   string `json:"BuildName,omitempty"`
+	BuildNumber string `json:"BuildNumber,omitempty"`
+}
+
 type BuildInfoConfiguration struct {
 	artDetails auth.ArtifactoryDetails
 	DryRun     bool
@@ -260,4 +371,6 @@ func (config *BuildInfoConfiguration) IsDryRun() bool {
 type BuildConfiguration struct {
 	BuildName   string
 	BuildNumber string
+	// The project configuration, whose repositories of the build tool are used if the build config file doesn't set them. May be nil.
+	ProjectConfig *config.ProjectConfig
 }
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	concurrentSteps       = 10
+	partialsPerStep       = 3
+	concurrentBuildName   = "concurrent-build"
+	concurrentBuildNumber = "1"
+	// Set when the test binary is executed as one of the concurrent steps of the build.
+	concurrentStepEnv = "JFROG_CLI_TEST_CONCURRENT_BUILD_STEP"
+)
+
+func TestConcurrentStepsGoroutines(t *testing.T) {
+	defer setBuildsDirForTest(t)()
+
+	var wg sync.WaitGroup
+	timestamps := make([]string, concurrentSteps)
+	errs := make([]error, concurrentSteps)
+	for i := 0; i < concurrentSteps; i++ {
+		wg.Add(1)
+		go func(step int) {
+			defer wg.Done()
+			timestamps[step], errs[step] = runConcurrentBuildStep(step)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	validateConcurrentBuild(t, timestamps)
+}
+
+func TestConcurrentStepsProcesses(t *testing.T) {
+	defer setBuildsDirForTest(t)()
+
+	commands := make([]*exec.Cmd, concurrentSteps)
+	outputs := make([]strings.Builder, concurrentSteps)
+	for i := range commands {
+		commands[i] = exec.Command(os.Args[0], "-test.run=TestConcurrentStepProcess")
+		commands[i].Env = append(os.Environ(), concurrentStepEnv+"="+strconv.Itoa(i))
+		commands[i].Stdout = &outputs[i]
+		commands[i].Stderr = os.Stderr
+		if err := commands[i].Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	timestamps := make([]string, concurrentSteps)
+	for i, command := range commands {
+		if err := command.Wait(); err != nil {
+			t.Fatal("Step", i, "failed:", err)
+		}
+		for _, line := range strings.Split(outputs[i].String(), "\n") {
+			if strings.HasPrefix(line, "timestamp=") {
+				timestamps[i] = strings.TrimPrefix(line, "timestamp=")
+			}
+		}
+	}
+	validateConcurrentBuild(t, timestamps)
+}
+
+// Runs a single step of the build, when executed by TestConcurrentStepsProcesses.
+func TestConcurrentStepProcess(t *testing.T) {
+	step, err := strconv.Atoi(os.Getenv(concurrentStepEnv))
+	if err != nil {
+		t.Skip("Executed only as a step of TestConcurrentStepsProcesses.")
+	}
+	timestamp, err := runConcurrentBuildStep(step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println("timestamp=" + timestamp)
+}
+
+func runConcurrentBuildStep(step int) (string, error) {
+	if err := SaveBuildGeneralDetails(concurrentBuildName, concurrentBuildNumber); err != nil {
+		return "", err
+	}
+	for i := 0; i < partialsPerStep; i++ {
+		err := SavePartialBuildInfo(concurrentBuildName, concurrentBuildNumber, func(partial *Partial) {
+			partial.Artifacts = NewArtifacts([]buildinfo.Artifact{{Name: fmt.Sprintf("step-%d-%d.txt", step, i)}})
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	details, err := ReadBuildInfoGeneralDetails(concurrentBuildName, concurrentBuildNumber)
+	if err != nil {
+		return "", err
+	}
+	return details.Timestamp.String(), nil
+}
+
+// Validates that all the steps share the same general details, and that none of the partials was lost or corrupted.
+func validateConcurrentBuild(t *testing.T, timestamps []string) {
+	for _, timestamp := range timestamps {
+		if timestamp == "" || timestamp != timestamps[0] {
+			t.Fatal("Expected all the steps to share the same build timestamp, got:", timestamps)
+		}
+	}
+	partials, err := ReadPartialBuildInfoFiles(concurrentBuildName, concurrentBuildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partials) != concurrentSteps*partialsPerStep {
+		t.Fatal("Expected", concurrentSteps*partialsPerStep, "partials, got:", len(partials))
+	}
+	artifacts := make(map[string]bool)
+	for _, partial := range partials {
+		if len(partial.Artifacts) != 1 {
+			t.Fatal("Unexpected partial:", partial)
+		}
+		artifacts[partial.Artifacts[0].Name] = true
+	}
+	if len(artifacts) != concurrentSteps*partialsPerStep {
+		t.Error("Expected", concurrentSteps*partialsPerStep, "distinct artifacts, got:", artifacts)
+	}
+}
+
+// Sets a temp builds directory, and returns a function which removes it.
+func setBuildsDirForTest(t *testing.T) func() {
+	tempDir, err := ioutil.TempDir("", "builds-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousBuildsDir := os.Getenv(config.BuildsDirEnv)
+	// The environment variable is inherited by the processes executed by the test.
+	os.Setenv(config.BuildsDirEnv, tempDir)
+	return func() {
+		os.Setenv(config.BuildsDirEnv, previousBuildsDir)
+		os.RemoveAll(tempDir)
+	}
+}
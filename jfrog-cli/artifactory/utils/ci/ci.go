@@ -0,0 +1,145 @@
+package ci
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Returns the value of an environment variable of the CI provider.
+type GetEnvFunc func(key string) string
+
+// Returns the details of the build if it runs on the CI provider, or nil otherwise.
+type Detector func(getEnv GetEnvFunc) *utils.CiDetails
+
+// The detectors of the supported CI providers, in the order they are tried.
+// To support another provider, add its detector to the list.
+var Detectors = []Detector{
+	detectJenkins,
+	detectGitLab,
+	detectGitHubActions,
+	detectAzurePipelines,
+	detectCircleCi,
+	detectTeamCity,
+}
+
+// Returns the details of the build collected by the first detector which recognizes the CI provider, or nil if none does.
+func Detect() *utils.CiDetails {
+	return detect(os.Getenv)
+}
+
+func detect(getEnv GetEnvFunc) *utils.CiDetails {
+	for _, detector := range Detectors {
+		if details := detector(getEnv); details != nil {
+			details.Vcs.Url = removeCredentials(details.Vcs.Url)
+			return details
+		}
+	}
+	return nil
+}
+
+func detectJenkins(getEnv GetEnvFunc) *utils.CiDetails {
+	if getEnv("JENKINS_URL") == "" {
+		return nil
+	}
+	branch := getEnv("BRANCH_NAME")
+	if branch == "" {
+		branch = strings.TrimPrefix(getEnv("GIT_BRANCH"), "origin/")
+	}
+	return &utils.CiDetails{
+		Ci:        utils.Ci{Provider: "Jenkins", JobName: getEnv("JOB_NAME"), JobId: getEnv("BUILD_NUMBER")},
+		BuildUrl:  getEnv("BUILD_URL"),
+		Principal: getEnv("BUILD_USER_ID"),
+		Vcs:       utils.Vcs{Url: getEnv("GIT_URL"), Revision: getEnv("GIT_COMMIT"), Branch: branch},
+	}
+}
+
+func detectGitLab(getEnv GetEnvFunc) *utils.CiDetails {
+	if getEnv("GITLAB_CI") == "" {
+		return nil
+	}
+	return &utils.CiDetails{
+		Ci:        utils.Ci{Provider: "GitLab CI", JobName: getEnv("CI_JOB_NAME"), JobId: getEnv("CI_JOB_ID")},
+		BuildUrl:  getEnv("CI_JOB_URL"),
+		Principal: getEnv("GITLAB_USER_LOGIN"),
+		Vcs:       utils.Vcs{Url: getEnv("CI_PROJECT_URL"), Revision: getEnv("CI_COMMIT_SHA"), Branch: getEnv("CI_COMMIT_REF_NAME"), Message: getEnv("CI_COMMIT_MESSAGE")},
+	}
+}
+
+func detectGitHubActions(getEnv GetEnvFunc) *utils.CiDetails {
+	if getEnv("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+	repositoryUrl := getEnv("GITHUB_SERVER_URL") + "/" + getEnv("GITHUB_REPOSITORY")
+	branch := getEnv("GITHUB_HEAD_REF")
+	if branch == "" {
+		branch = strings.TrimPrefix(getEnv("GITHUB_REF"), "refs/heads/")
+	}
+	return &utils.CiDetails{
+		Ci:        utils.Ci{Provider: "GitHub Actions", JobName: joinNonEmpty(getEnv("GITHUB_WORKFLOW"), getEnv("GITHUB_JOB")), JobId: getEnv("GITHUB_RUN_ID")},
+		BuildUrl:  repositoryUrl + "/actions/runs/" + getEnv("GITHUB_RUN_ID"),
+		Principal: getEnv("GITHUB_ACTOR"),
+		Vcs:       utils.Vcs{Url: repositoryUrl, Revision: getEnv("GITHUB_SHA"), Branch: branch},
+	}
+}
+
+func detectAzurePipelines(getEnv GetEnvFunc) *utils.CiDetails {
+	if !strings.EqualFold(getEnv("TF_BUILD"), "true") {
+		return nil
+	}
+	buildUrl := ""
+	if collectionUri := getEnv("SYSTEM_TEAMFOUNDATIONCOLLECTIONURI"); collectionUri != "" {
+		buildUrl = strings.TrimSuffix(collectionUri, "/") + "/" + getEnv("SYSTEM_TEAMPROJECT") + "/_build/results?buildId=" + getEnv("BUILD_BUILDID")
+	}
+	return &utils.CiDetails{
+		Ci:        utils.Ci{Provider: "Azure Pipelines", JobName: getEnv("BUILD_DEFINITIONNAME"), JobId: getEnv("BUILD_BUILDID")},
+		BuildUrl:  buildUrl,
+		Principal: getEnv("BUILD_REQUESTEDFOR"),
+		Vcs:       utils.Vcs{Url: getEnv("BUILD_REPOSITORY_URI"), Revision: getEnv("BUILD_SOURCEVERSION"), Branch: getEnv("BUILD_SOURCEBRANCHNAME"), Message: getEnv("BUILD_SOURCEVERSIONMESSAGE")},
+	}
+}
+
+func detectCircleCi(getEnv GetEnvFunc) *utils.CiDetails {
+	if getEnv("CIRCLECI") != "true" {
+		return nil
+	}
+	return &utils.CiDetails{
+		Ci:        utils.Ci{Provider: "CircleCI", JobName: getEnv("CIRCLE_JOB"), JobId: getEnv("CIRCLE_BUILD_NUM")},
+		BuildUrl:  getEnv("CIRCLE_BUILD_URL"),
+		Principal: getEnv("CIRCLE_USERNAME"),
+		Vcs:       utils.Vcs{Url: getEnv("CIRCLE_REPOSITORY_URL"), Revision: getEnv("CIRCLE_SHA1"), Branch: getEnv("CIRCLE_BRANCH")},
+	}
+}
+
+// TeamCity exposes only a few of the build details as environment variables by default.
+func detectTeamCity(getEnv GetEnvFunc) *utils.CiDetails {
+	if getEnv("TEAMCITY_VERSION") == "" {
+		return nil
+	}
+	return &utils.CiDetails{
+		Ci:  utils.Ci{Provider: "TeamCity", JobName: joinNonEmpty(getEnv("TEAMCITY_PROJECT_NAME"), getEnv("TEAMCITY_BUILDCONF_NAME")), JobId: getEnv("BUILD_NUMBER")},
+		Vcs: utils.Vcs{Revision: getEnv("BUILD_VCS_NUMBER")},
+	}
+}
+
+// The URL of the repository may include credentials, such as the user:token@ of the Git URL of Jenkins, which must not be added to the build-info.
+// URLs which cannot be parsed, such as the SCP-like URLs of SSH, are returned as is.
+func removeCredentials(vcsUrl string) string {
+	parsedUrl, err := url.Parse(vcsUrl)
+	if err != nil || parsedUrl.User == nil {
+		return vcsUrl
+	}
+	parsedUrl.User = nil
+	return parsedUrl.String()
+}
+
+func joinNonEmpty(values ...string) string {
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return strings.Join(nonEmpty, " / ")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -251,7 +252,7 @@ func CreateServiceManager(artDetails *config.ArtifactoryDetails, threads int) (*
 	if err != nil {
 		return nil, err
 	}
-	artAuth, err := artDetails.CreateArtAuthConfig()
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -278,7 +279,12 @@ func DockerLogin(imageTag string, config *DockerLoginConfig) error {
 		return err
 	}
 
-	cmd := &LoginCmd{DockerRegistry: imageRegistry, Username: config.ArtifactoryDetails.User, Password: config.ArtifactoryDetails.Password}
+	user, password, err := config.ArtifactoryDetails.GetBasicAuthCredentials(lock.LockConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := &LoginCmd{DockerRegistry: imageRegistry, Username: user, Password: password}
 	err = utils.RunCmd(cmd)
 
 	if exitCode := cliutils.GetExitCode(err, 0, 0, false); exitCode == cliutils.ExitCodeNoError {
@@ -291,7 +297,7 @@ func DockerLogin(imageTag string, config *DockerLoginConfig) error {
 		return errorutils.CheckError(errors.New(fmt.Sprintf(DockerLoginFailureMessage, imageRegistry)))
 	}
 
-	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ArtifactoryDetails.User, Password: config.ArtifactoryDetails.Password}
+	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: user, Password: password}
 	err = utils.RunCmd(cmd)
 	if err != nil {
 		// Login failed for both attempts
@@ -4,27 +4,38 @@ import (
 	"bufio"
 	"errors"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/src-d/go-git.v4"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 type manager struct {
-	path     string
-	err      error
-	revision string
-	url      string
+	// The directory of the git working tree.
+	workTree string
+	// The git directory of the working tree. For a linked worktree, this is its directory under the main repository git directory.
+	path string
+	// The git directory shared by all the worktrees, which holds the refs and objects.
+	commonPath string
+	err        error
+	revision   string
+	url        string
+	branch     string
+	repository *git.Repository
 }
 
 func NewManager(path string) *manager {
 	dotGitPath := filepath.Join(path, ".git")
-	return &manager{path: dotGitPath}
+	return &manager{workTree: path, path: dotGitPath, commonPath: dotGitPath}
 }
 
 func (m *manager) ReadConfig() error {
 	if m.path == "" {
 		return errorutils.CheckError(errors.New(".git path must be defined."))
 	}
+	m.resolveGitDir()
 	m.readRevision()
 	m.readUrl()
 	return m.err
@@ -38,11 +49,58 @@ func (m *manager) GetRevision() string {
 	return m.revision
 }
 
+// Returns the checked out branch. If HEAD is detached, returns a branch which points at the checked out revision, if there is one.
+func (m *manager) GetBranch() string {
+	return m.branch
+}
+
+// In linked worktrees and submodules, .git is a file which points at the git directory.
+// The git directory of a linked worktree also points at the main git directory, which is shared by all the worktrees.
+func (m *manager) resolveGitDir() {
+	if m.err != nil {
+		return
+	}
+	info, err := os.Stat(m.path)
+	if errorutils.CheckError(err) != nil {
+		m.err = err
+		return
+	}
+	if !info.IsDir() {
+		gitDir, err := readPointerFile(m.path, "gitdir:")
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.path, m.commonPath = gitDir, gitDir
+	}
+	commonDir, err := readPointerFile(filepath.Join(m.path, "commondir"), "")
+	if err == nil {
+		m.commonPath = commonDir
+	}
+}
+
+// Reads a file which holds a path, optionally after a prefix. Relative paths are relative to the directory of the file.
+func readPointerFile(path, prefix string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	pointer := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(pointer, prefix) {
+		return "", errorutils.CheckError(errors.New("Unexpected content in " + path + ": " + pointer))
+	}
+	pointer = strings.TrimSpace(strings.TrimPrefix(pointer, prefix))
+	if !filepath.IsAbs(pointer) {
+		pointer = filepath.Join(filepath.Dir(path), pointer)
+	}
+	return filepath.Clean(pointer), nil
+}
+
 func (m *manager) readUrl() {
 	if m.err != nil {
 		return
 	}
-	dotGitPath := filepath.Join(m.path, "config")
+	dotGitPath := filepath.Join(m.commonPath, "config")
 	file, err := os.Open(dotGitPath)
 	if errorutils.CheckError(err) != nil {
 		m.err = err
@@ -109,33 +167,117 @@ func (m *manager) readRevision() {
 		m.err = err
 		return
 	}
-	// If the revision was returned, then we're done:
+	// If the revision was returned, then HEAD is detached:
 	if revision != "" {
-		m.revision = revision
+		m.revision = strings.TrimSpace(revision)
+		m.branch = m.findBranch(m.revision)
 		return
 	}
 
 	// Since the revision was not returned, then we'll fetch it, by using the ref:
-	dotGitPath := filepath.Join(m.path, ref)
-	file, err := os.Open(dotGitPath)
+	m.branch = strings.TrimPrefix(ref, "refs/heads/")
+	refs, err := m.readRefs()
 	if err != nil {
 		m.err = err
 		return
 	}
-	defer file.Close()
+	revision, exists := refs[ref]
+	if !exists {
+		m.err = errorutils.CheckError(errors.New("Could not find the revision of " + ref + " in " + m.commonPath))
+		return
+	}
+	m.revision = revision
+}
 
+// Returns the revisions of the refs, from both the packed-refs file and the loose ref files, which take precedence.
+func (m *manager) readRefs() (map[string]string, error) {
+	refs, err := readPackedRefs(filepath.Join(m.commonPath, "packed-refs"))
+	if err != nil {
+		return nil, err
+	}
+	refsDir := filepath.Join(m.commonPath, "refs")
+	err = filepath.Walk(refsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		revision := strings.TrimSpace(string(content))
+		if strings.HasPrefix(revision, "ref:") {
+			// Symbolic refs, such as refs/remotes/origin/HEAD, don't point at a revision.
+			return nil
+		}
+		relativePath, err := filepath.Rel(m.commonPath, path)
+		if err != nil {
+			return err
+		}
+		refs[filepath.ToSlash(relativePath)] = revision
+		return nil
+	})
+	return refs, errorutils.CheckError(err)
+}
+
+func readPackedRefs(path string) (map[string]string, error) {
+	refs := make(map[string]string)
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return refs, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		text := scanner.Text()
-		revision = strings.TrimSpace(text)
-		break
-	}
-	if err := scanner.Err(); err != nil {
-		errorutils.CheckError(err)
-		m.err = err
-		return
+		// Lines starting with '#' are comments, and lines starting with '^' hold the revisions peeled annotated tags point at.
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			refs[fields[1]] = fields[0]
+		}
 	}
+	return refs, errorutils.CheckError(scanner.Err())
+}
 
-	m.revision = revision
-	return
+// Returns the name of a branch which points at the revision, preferring local branches over remote branches.
+// Returns an empty string if there is none.
+func (m *manager) findBranch(revision string) string {
+	refs, err := m.readRefs()
+	if err != nil {
+		return ""
+	}
+	var localBranches, remoteBranches []string
+	for ref, refRevision := range refs {
+		if refRevision != revision {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			localBranches = append(localBranches, strings.TrimPrefix(ref, "refs/heads/"))
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			// Remote branches are named after their remote, such as origin/master.
+			remoteBranch := strings.TrimPrefix(ref, "refs/remotes/")
+			if parts := strings.SplitN(remoteBranch, "/", 2); len(parts) == 2 {
+				remoteBranches = append(remoteBranches, parts[1])
+			}
+		}
+	}
+	for _, branches := range [][]string{localBranches, remoteBranches} {
+		if len(branches) > 0 {
+			sort.Strings(branches)
+			return branches[0]
+		}
+	}
+	return ""
 }
@@ -0,0 +1,178 @@
+package git
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Creates a repository with two commits, and returns its path and the revisions of the commits.
+func createTestRepository(t *testing.T) (string, []string) {
+	path, err := ioutil.TempDir("", "git_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository, err := git.PlainInit(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/jfrog/test.git"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var revisions []string
+	for i, files := range [][]string{{"a.txt", "b.txt"}, {"b.txt", "c.txt"}} {
+		for _, file := range files {
+			writeFile(t, filepath.Join(path, file), "content "+strconv.Itoa(i))
+			if _, err = worktree.Add(file); err != nil {
+				t.Fatal(err)
+			}
+		}
+		author := &object.Signature{Name: "User", Email: "user@example.com", When: time.Now()}
+		hash, err := worktree.Commit("Commit "+strconv.Itoa(i+1)+"\n", &git.CommitOptions{Author: author})
+		if err != nil {
+			t.Fatal(err)
+		}
+		revisions = append(revisions, hash.String())
+	}
+	return path, revisions
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadRepository(t *testing.T) {
+	path, revisions := createTestRepository(t)
+	defer os.RemoveAll(path)
+
+	manager := NewManager(path)
+	if err := manager.ReadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetRevision() != revisions[1] || manager.GetBranch() != "master" || manager.GetUrl() != "https://github.com/jfrog/test.git" {
+		t.Error("Unexpected revision, branch or url:", manager.GetRevision(), manager.GetBranch(), manager.GetUrl())
+	}
+	commit, err := manager.ReadCommit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Message != "Commit 2" || commit.Author != "User <user@example.com>" {
+		t.Error("Unexpected commit details:", commit)
+	}
+	changedFiles, err := manager.GetChangedFiles(revisions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changedFiles, []string{"b.txt", "c.txt"}) {
+		t.Error("Unexpected changed files:", changedFiles)
+	}
+	for _, test := range []struct {
+		fromRevision string
+		limit        int
+		expected     []string
+	}{
+		{revisions[0], 0, []string{"Commit 2"}},
+		{"", 0, []string{"Commit 2", "Commit 1"}},
+		{"", 1, []string{"Commit 2"}},
+	} {
+		messages, err := manager.GetCommitMessages(test.fromRevision, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(messages, test.expected) {
+			t.Error("Unexpected commit messages:", messages)
+		}
+	}
+
+	// Untracked files don't make the working tree dirty, but modified files do.
+	writeFile(t, filepath.Join(path, "untracked.txt"), "content")
+	if dirty, err := manager.IsDirty(); err != nil || dirty {
+		t.Error("Expected the working tree to be clean, got:", dirty, err)
+	}
+	writeFile(t, filepath.Join(path, "a.txt"), "modified")
+	if dirty, err := manager.IsDirty(); err != nil || !dirty {
+		t.Error("Expected the working tree to be dirty, got:", dirty, err)
+	}
+}
+
+func TestReadPackedRefsAndDetachedHead(t *testing.T) {
+	path, revisions := createTestRepository(t)
+	defer os.RemoveAll(path)
+	dotGit := filepath.Join(path, ".git")
+
+	// Move the branch ref to packed-refs, as git gc does.
+	if err := os.Remove(filepath.Join(dotGit, "refs", "heads", "master")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dotGit, "packed-refs"), "# pack-refs with: peeled fully-peeled sorted\n"+
+		revisions[1]+" refs/heads/master\n"+revisions[0]+" refs/remotes/origin/release\n")
+	manager := NewManager(path)
+	if err := manager.ReadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetRevision() != revisions[1] || manager.GetBranch() != "master" {
+		t.Error("Unexpected revision or branch:", manager.GetRevision(), manager.GetBranch())
+	}
+
+	// With a detached HEAD, the branch is found by its revision.
+	writeFile(t, filepath.Join(dotGit, "HEAD"), revisions[0]+"\n")
+	manager = NewManager(path)
+	if err := manager.ReadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetRevision() != revisions[0] || manager.GetBranch() != "release" {
+		t.Error("Unexpected revision or branch:", manager.GetRevision(), manager.GetBranch())
+	}
+}
+
+func TestReadLinkedWorktree(t *testing.T) {
+	path, revisions := createTestRepository(t)
+	defer os.RemoveAll(path)
+	dotGit := filepath.Join(path, ".git")
+
+	// A linked worktree, as created by git worktree add, which checks out the first commit on a new branch.
+	worktreePath := filepath.Join(path, "linked")
+	worktreeGitDir := filepath.Join(dotGit, "worktrees", "linked")
+	for _, dir := range []string{worktreePath, worktreeGitDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(worktreePath, ".git"), "gitdir: "+worktreeGitDir+"\n")
+	writeFile(t, filepath.Join(worktreeGitDir, "commondir"), "../..\n")
+	writeFile(t, filepath.Join(worktreeGitDir, "HEAD"), "ref: refs/heads/feature\n")
+	writeFile(t, filepath.Join(dotGit, "refs", "heads", "feature"), revisions[0]+"\n")
+
+	manager := NewManager(worktreePath)
+	if err := manager.ReadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetRevision() != revisions[0] || manager.GetBranch() != "feature" || manager.GetUrl() != "https://github.com/jfrog/test.git" {
+		t.Error("Unexpected revision, branch or url:", manager.GetRevision(), manager.GetBranch(), manager.GetUrl())
+	}
+	commit, err := manager.ReadCommit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Message != "Commit 1" {
+		t.Error("Unexpected commit message:", commit.Message)
+	}
+	if _, err := manager.getCommit(plumbing.ZeroHash.String()); err == nil {
+		t.Error("Expected an error for a missing commit.")
+	}
+}
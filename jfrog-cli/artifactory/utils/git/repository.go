@@ -0,0 +1,232 @@
+package git
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/src-d/go-billy.v4"
+	"gopkg.in/src-d/go-billy.v4/osfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The details of the checked out commit, read from the git objects.
+type Commit struct {
+	Message string
+	// The author, in the form of "name <email>".
+	Author string
+}
+
+type Submodule struct {
+	Path string
+	Url  string
+	// The checked out revision of the submodule, or the revision recorded by the repository if the submodule isn't checked out.
+	Revision string
+}
+
+// Reads the message and author of the checked out commit.
+// Must be called after ReadConfig.
+func (m *manager) ReadCommit() (*Commit, error) {
+	commit, err := m.getCommit(m.revision)
+	if err != nil {
+		return nil, err
+	}
+	return &Commit{Message: strings.TrimSpace(commit.Message), Author: commit.Author.Name + " <" + commit.Author.Email + ">"}, nil
+}
+
+// Returns true if the working tree has changes which weren't committed. Untracked files aren't considered changes.
+func (m *manager) IsDirty() (bool, error) {
+	worktree, err := m.getWorktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	for _, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked {
+			continue
+		}
+		if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *manager) GetSubmodules() ([]Submodule, error) {
+	worktree, err := m.getWorktree()
+	if err != nil {
+		return nil, err
+	}
+	gitSubmodules, err := worktree.Submodules()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var submodules []Submodule
+	for _, gitSubmodule := range gitSubmodules {
+		status, err := gitSubmodule.Status()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		revision := status.Current
+		if revision.IsZero() {
+			revision = status.Expected
+		}
+		submodules = append(submodules, Submodule{Path: gitSubmodule.Config().Path, Url: gitSubmodule.Config().URL, Revision: revision.String()})
+	}
+	sort.Slice(submodules, func(i, j int) bool {
+		return submodules[i].Path < submodules[j].Path
+	})
+	return submodules, nil
+}
+
+// Returns the paths of the files which changed between the received revision and the checked out revision, sorted.
+// Must be called after ReadConfig.
+func (m *manager) GetChangedFiles(fromRevision string) ([]string, error) {
+	fromCommit, err := m.getCommit(fromRevision)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := m.getCommit(m.revision)
+	if err != nil {
+		return nil, err
+	}
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	changedFiles := make(map[string]bool)
+	for _, change := range changes {
+		// Renamed files are reported both by their old and new paths.
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				changedFiles[name] = true
+			}
+		}
+	}
+	var paths []string
+	for path := range changedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Returns the messages of the commits reachable from the checked out revision and not from the received revision, as git log from..HEAD does.
+// If the received revision is empty, the commits reachable from the checked out revision are returned. If limit is positive, at most limit messages are returned.
+// Must be called after ReadConfig.
+func (m *manager) GetCommitMessages(fromRevision string, limit int) ([]string, error) {
+	toCommit, err := m.getCommit(m.revision)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[plumbing.Hash]bool)
+	if fromRevision != "" {
+		fromCommit, err := m.getCommit(fromRevision)
+		if err != nil {
+			return nil, err
+		}
+		err = object.NewCommitPreorderIter(fromCommit, nil, nil).ForEach(func(commit *object.Commit) error {
+			excluded[commit.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	var messages []string
+	err = object.NewCommitPreorderIter(toCommit, excluded, nil).ForEach(func(commit *object.Commit) error {
+		if limit > 0 && len(messages) == limit {
+			return storer.ErrStop
+		}
+		messages = append(messages, strings.TrimSpace(commit.Message))
+		return nil
+	})
+	return messages, errorutils.CheckError(err)
+}
+
+func (m *manager) getCommit(revision string) (*object.Commit, error) {
+	if len(revision) != 40 {
+		return nil, errorutils.CheckError(errors.New("Invalid git revision: " + revision))
+	}
+	repository, err := m.getRepository()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(plumbing.NewHash(revision))
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Could not read commit " + revision + ": " + err.Error()))
+	}
+	return commit, nil
+}
+
+func (m *manager) getWorktree() (*git.Worktree, error) {
+	repository, err := m.getRepository()
+	if err != nil {
+		return nil, err
+	}
+	worktree, err := repository.Worktree()
+	return worktree, errorutils.CheckError(err)
+}
+
+// Opens the repository in which the git objects are read. Must be called after ReadConfig.
+func (m *manager) getRepository() (*git.Repository, error) {
+	if m.repository != nil {
+		return m.repository, nil
+	}
+	var dotGit billy.Filesystem = osfs.New(m.commonPath)
+	if m.path != m.commonPath {
+		dotGit = &linkedWorktreeFs{Filesystem: dotGit, worktreeGitDir: osfs.New(m.path)}
+	}
+	storage, err := filesystem.NewStorage(dotGit)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	m.repository, err = git.Open(storage, osfs.New(m.workTree))
+	return m.repository, errorutils.CheckError(err)
+}
+
+// The git directory of a linked worktree holds its own HEAD and index, and shares the rest with the main git directory.
+type linkedWorktreeFs struct {
+	billy.Filesystem
+	worktreeGitDir billy.Filesystem
+}
+
+func (fs *linkedWorktreeFs) getFs(filename string) billy.Filesystem {
+	filename = strings.Replace(filename, "\\", "/", -1)
+	if filename == "HEAD" || filename == "ORIG_HEAD" || filename == "index" || strings.HasPrefix(filename, "logs/HEAD") {
+		return fs.worktreeGitDir
+	}
+	return fs.Filesystem
+}
+
+func (fs *linkedWorktreeFs) Open(filename string) (billy.File, error) {
+	return fs.getFs(filename).Open(filename)
+}
+
+func (fs *linkedWorktreeFs) OpenFile(filename string, flag int, perm os.FileMode) (billy.File, error) {
+	return fs.getFs(filename).OpenFile(filename, flag, perm)
+}
+
+func (fs *linkedWorktreeFs) Stat(filename string) (os.FileInfo, error) {
+	return fs.getFs(filename).Stat(filename)
+}
+
+func (fs *linkedWorktreeFs) Lstat(filename string) (os.FileInfo, error) {
+	return fs.getFs(filename).Lstat(filename)
+}
@@ -3,6 +3,7 @@ package golang
 import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/mattn/go-shellwords"
 	"io"
@@ -54,7 +55,11 @@ func SetGoProxyEnvVar(artifactoryDetails *config.ArtifactoryDetails, repoName st
 	if err != nil {
 		return err
 	}
-	rtUrl.User = url.UserPassword(artifactoryDetails.User, artifactoryDetails.Password)
+	user, password, err := artifactoryDetails.GetBasicAuthCredentials(lock.LockConfig)
+	if err != nil {
+		return err
+	}
+	rtUrl.User = url.UserPassword(user, password)
 	rtUrl.Path += "api/go/" + repoName
 
 	err = os.Setenv(GOPROXY, rtUrl.String())
@@ -88,7 +93,7 @@ func RunGo(goArg string) error {
 	}
 
 	protocolRegExp := utils.CmdOutputPattern{
-		RegExp:    regExp,
+		RegExp: regExp,
 	}
 	protocolRegExp.ExecFunc = protocolRegExp.MaskCredentials
 
@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A build which was collected locally and wasn't published yet.
+type LocalBuild struct {
+	BuildName   string `json:"buildName"`
+	BuildNumber string `json:"buildNumber"`
+	Modules     int    `json:"modules"`
+	Started     string `json:"started,omitempty"`
+	LastUpdated string `json:"lastUpdated"`
+	Age         string `json:"age"`
+	dir         string
+	lastUpdated time.Time
+}
+
+// Returns the builds collected locally, sorted by their last update time.
+func ListLocalBuilds() ([]*LocalBuild, error) {
+	buildDirs, err := listBuildDirs()
+	if err != nil {
+		return nil, err
+	}
+	localBuilds := []*LocalBuild{}
+	for _, buildDir := range buildDirs {
+		localBuild, err := readLocalBuild(buildDir)
+		if err != nil {
+			return nil, err
+		}
+		if localBuild == nil {
+			continue
+		}
+		if localBuild.Modules, err = countModules(localBuild.BuildName, localBuild.BuildNumber); err != nil {
+			return nil, err
+		}
+		localBuilds = append(localBuilds, localBuild)
+	}
+	sort.Slice(localBuilds, func(i, j int) bool {
+		return localBuilds[i].lastUpdated.Before(localBuilds[j].lastUpdated)
+	})
+	return localBuilds, nil
+}
+
+// Removes the builds collected locally which weren't updated for longer than the builds expiry period.
+func RemoveExpiredBuilds() error {
+	expiryDays, err := config.GetBuildsExpiryDays()
+	if err != nil || expiryDays <= 0 {
+		return err
+	}
+	buildDirs, err := listBuildDirs()
+	if err != nil {
+		return err
+	}
+	expiry := time.Now().AddDate(0, 0, -expiryDays)
+	for _, buildDir := range buildDirs {
+		localBuild, err := readLocalBuild(buildDir)
+		if err != nil {
+			return err
+		}
+		if localBuild == nil || localBuild.lastUpdated.After(expiry) {
+			continue
+		}
+		if err = removeExpiredBuild(localBuild, expiry); err != nil {
+			return err
+		}
+	}
+	return removeUnusedBuildLocks(expiry)
+}
+
+func removeExpiredBuild(localBuild *LocalBuild, expiry time.Time) error {
+	lockFile, err := lockBuild(localBuild.BuildName, localBuild.BuildNumber)
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
+	// The build may have been updated or removed while waiting for the lock.
+	exists, err := fileutils.IsDirExists(localBuild.dir, false)
+	if err != nil || !exists {
+		return err
+	}
+	lastUpdated, err := getLastModified(localBuild.dir)
+	if err != nil || lastUpdated.After(expiry) {
+		return err
+	}
+	log.Info("Removing build", localBuild.BuildName+"/"+localBuild.BuildNumber, "which wasn't updated since", localBuild.LastUpdated+".")
+	return errorutils.CheckError(os.RemoveAll(localBuild.dir))
+}
+
+// Removes the lock directories of the builds which were removed, if they weren't used since the expiry.
+func removeUnusedBuildLocks(expiry time.Time) error {
+	buildsDir, err := config.GetBuildsDir()
+	if err != nil {
+		return err
+	}
+	locksDir := filepath.Join(buildsDir, buildsLocksDir)
+	exists, err := fileutils.IsDirExists(locksDir, false)
+	if err != nil || !exists {
+		return err
+	}
+	files, err := ioutil.ReadDir(locksDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, file := range files {
+		if !file.IsDir() || file.ModTime().After(expiry) {
+			continue
+		}
+		buildExists, err := fileutils.IsDirExists(filepath.Join(buildsDir, file.Name()), false)
+		if err != nil {
+			return err
+		}
+		if !buildExists {
+			// A lock directory which is in use isn't empty, and therefore isn't removed.
+			os.Remove(filepath.Join(locksDir, file.Name()))
+		}
+	}
+	return nil
+}
+
+func listBuildDirs() ([]string, error) {
+	buildsDir, err := config.GetBuildsDir()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsDirExists(buildsDir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(buildsDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var buildDirs []string
+	for _, file := range files {
+		if file.IsDir() && file.Name() != buildsLocksDir {
+			buildDirs = append(buildDirs, filepath.Join(buildsDir, file.Name()))
+		}
+	}
+	return buildDirs, nil
+}
+
+// Returns nil if the directory isn't a build directory.
+func readLocalBuild(buildDir string) (*LocalBuild, error) {
+	decodedDirName, err := base64.StdEncoding.DecodeString(filepath.Base(buildDir))
+	if err != nil {
+		return nil, nil
+	}
+	localBuild := &LocalBuild{dir: buildDir}
+	details := new(buildGeneralDetails)
+	content, err := ioutil.ReadFile(filepath.Join(buildDir, "partials", BuildInfoDetails))
+	if err == nil {
+		json.Unmarshal(content, details)
+	}
+	if details.BuildName != "" {
+		localBuild.BuildName, localBuild.BuildNumber = details.BuildName, details.BuildNumber
+	} else {
+		// Builds collected by older versions don't keep their names and numbers, so they are decoded from the directory name.
+		separator := strings.LastIndex(string(decodedDirName), "_")
+		if separator < 0 {
+			return nil, nil
+		}
+		localBuild.BuildName, localBuild.BuildNumber = string(decodedDirName[:separator]), string(decodedDirName[separator+1:])
+	}
+	if !details.Timestamp.IsZero() {
+		localBuild.Started = details.Timestamp.Format(time.RFC3339)
+	}
+	if localBuild.lastUpdated, err = getLastModified(buildDir); err != nil {
+		return nil, err
+	}
+	localBuild.LastUpdated = localBuild.lastUpdated.Format(time.RFC3339)
+	started := localBuild.lastUpdated
+	if !details.Timestamp.IsZero() {
+		started = details.Timestamp
+	}
+	localBuild.Age = time.Since(started).Round(time.Second).String()
+	return localBuild, nil
+}
+
+// Returns the latest modification time of the directory and the files under it.
+func getLastModified(dir string) (time.Time, error) {
+	var lastModified time.Time
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(lastModified) {
+			lastModified = info.ModTime()
+		}
+		return nil
+	})
+	return lastModified, errorutils.CheckError(err)
+}
+
+// Counts the modules of the build, the same way they are created when the build is published.
+func countModules(buildName, buildNumber string) (int, error) {
+	moduleIds := make(map[string]bool)
+	partials, err := ReadPartialBuildInfoFiles(buildName, buildNumber)
+	if err != nil {
+		return 0, err
+	}
+	for _, partial := range partials {
+		if partial.Artifacts == nil && partial.Dependencies == nil {
+			continue
+		}
+		moduleId := partial.ModuleId
+		if moduleId == "" {
+			moduleId = buildName
+		}
+		moduleIds[moduleId] = true
+	}
+	generatedBuildsInfo, err := GetGeneratedBuildsInfo(buildName, buildNumber)
+	if err != nil {
+		return 0, err
+	}
+	for _, buildInfo := range generatedBuildsInfo {
+		for _, module := range buildInfo.Modules {
+			moduleIds[module.Id] = true
+		}
+	}
+	return len(moduleIds), nil
+}
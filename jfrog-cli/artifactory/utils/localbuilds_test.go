@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListAndRemoveExpiredBuilds(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "local-builds-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	previousBuildsDir := os.Getenv(config.BuildsDirEnv)
+	os.Setenv(config.BuildsDirEnv, tempDir)
+	defer os.Setenv(config.BuildsDirEnv, previousBuildsDir)
+	previousExpiryDays := os.Getenv(config.BuildsExpiryDaysEnv)
+	os.Setenv(config.BuildsExpiryDaysEnv, "1")
+	defer os.Setenv(config.BuildsExpiryDaysEnv, previousExpiryDays)
+
+	// The build name contains the separator of the build name and number in the build directory name.
+	saveLocalBuild(t, "my_build", "1", "module-a", "module-b", "")
+	saveLocalBuild(t, "abandoned", "2", "")
+	abandonedDir, err := getBuildDirPath("abandoned", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setModTime(t, abandonedDir, time.Now().AddDate(0, 0, -2))
+
+	localBuilds, err := ListLocalBuilds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(localBuilds) != 2 {
+		t.Fatal("Expected 2 local builds, got:", localBuilds)
+	}
+	// Sorted by the last update time.
+	if localBuilds[0].BuildName != "abandoned" || localBuilds[0].Modules != 1 {
+		t.Error("Unexpected local build:", localBuilds[0])
+	}
+	if localBuilds[1].BuildName != "my_build" || localBuilds[1].BuildNumber != "1" || localBuilds[1].Modules != 3 || localBuilds[1].Started == "" {
+		t.Error("Unexpected local build:", localBuilds[1])
+	}
+
+	if err = RemoveExpiredBuilds(); err != nil {
+		t.Fatal(err)
+	}
+	localBuilds, err = ListLocalBuilds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(localBuilds) != 1 || localBuilds[0].BuildName != "my_build" {
+		t.Error("Expected only the abandoned build to be removed, got:", localBuilds)
+	}
+
+	// Zero disables the expiry.
+	os.Setenv(config.BuildsExpiryDaysEnv, "0")
+	myBuildDir, err := getBuildDirPath("my_build", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setModTime(t, myBuildDir, time.Now().AddDate(-1, 0, 0))
+	if err = RemoveExpiredBuilds(); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := IsLocalBuildExists("my_build", "1"); err != nil || !exists {
+		t.Error("Expected the build not to be removed when the expiry is disabled.", err)
+	}
+}
+
+func saveLocalBuild(t *testing.T, buildName, buildNumber string, moduleIds ...string) {
+	if err := SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	for _, moduleId := range moduleIds {
+		err := SavePartialBuildInfo(buildName, buildNumber, func(partial *Partial) {
+			partial.Artifacts = NewArtifacts([]buildinfo.Artifact{{Name: "a.txt"}})
+			partial.ModuleId = moduleId
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func setModTime(t *testing.T, dir string, modTime time.Time) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, modTime, modTime)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,125 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
+	"time"
+)
+
+// The CycloneDX 1.4 JSON document, as defined in https://cyclonedx.org/docs/1.4/json/
+type CycloneDxDocument struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDxMetadata     `json:"metadata"`
+	Components   []CycloneDxComponent  `json:"components"`
+	Dependencies []CycloneDxDependency `json:"dependencies"`
+}
+
+type CycloneDxMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []CycloneDxTool    `json:"tools"`
+	Component CycloneDxComponent `json:"component"`
+}
+
+type CycloneDxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type CycloneDxComponent struct {
+	Type       string               `json:"type"`
+	BomRef     string               `json:"bom-ref"`
+	Group      string               `json:"group,omitempty"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version,omitempty"`
+	Purl       string               `json:"purl,omitempty"`
+	Hashes     []CycloneDxHash      `json:"hashes,omitempty"`
+	Properties []CycloneDxProperty  `json:"properties,omitempty"`
+	Components []CycloneDxComponent `json:"components,omitempty"`
+}
+
+type CycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type CycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+const cycloneDxBuildRef = "build"
+
+func createCycloneDx(buildInfo *utils.BuildInfo, packages *buildPackages, uuid string, created time.Time) *CycloneDxDocument {
+	document := &CycloneDxDocument{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + uuid,
+		Version:      1,
+		Metadata: CycloneDxMetadata{
+			Timestamp: created.Format(time.RFC3339),
+			Tools:     []CycloneDxTool{{Vendor: "JFrog", Name: cliutils.ClientAgent, Version: cliutils.GetVersion()}},
+			Component: CycloneDxComponent{Type: "application", BomRef: cycloneDxBuildRef, Name: buildInfo.Name, Version: buildInfo.Number},
+		},
+		Components:   []CycloneDxComponent{},
+		Dependencies: []CycloneDxDependency{},
+	}
+	buildDependency := CycloneDxDependency{Ref: cycloneDxBuildRef, DependsOn: []string{}}
+	for _, module := range packages.modules {
+		componentType := "application"
+		if module.moduleType == Docker {
+			componentType = "container"
+		}
+		component := toCycloneDxComponent(module.module, componentType)
+		for _, artifact := range module.artifacts {
+			component.Components = append(component.Components, toCycloneDxComponent(artifact, "file"))
+		}
+		document.Components = append(document.Components, component)
+		buildDependency.DependsOn = append(buildDependency.DependsOn, component.BomRef)
+
+		moduleDependency := CycloneDxDependency{Ref: component.BomRef, DependsOn: []string{}}
+		for _, dependency := range module.dependencies {
+			moduleDependency.DependsOn = append(moduleDependency.DependsOn, dependency.ref)
+		}
+		document.Dependencies = append(document.Dependencies, moduleDependency)
+	}
+	for _, dependency := range packages.dependencies {
+		document.Components = append(document.Components, toCycloneDxComponent(dependency, "library"))
+		document.Dependencies = append(document.Dependencies, CycloneDxDependency{Ref: dependency.ref, DependsOn: []string{}})
+	}
+	document.Dependencies = append([]CycloneDxDependency{buildDependency}, document.Dependencies...)
+	return document
+}
+
+func toCycloneDxComponent(sbomPackage *sbomPackage, componentType string) CycloneDxComponent {
+	component := CycloneDxComponent{
+		Type:    componentType,
+		BomRef:  sbomPackage.ref,
+		Group:   sbomPackage.group,
+		Name:    sbomPackage.name,
+		Version: sbomPackage.version,
+		Purl:    sbomPackage.purl,
+	}
+	if sbomPackage.sha1 != "" {
+		component.Hashes = append(component.Hashes, CycloneDxHash{Alg: "SHA-1", Content: sbomPackage.sha1})
+	}
+	if sbomPackage.sha256 != "" {
+		component.Hashes = append(component.Hashes, CycloneDxHash{Alg: "SHA-256", Content: sbomPackage.sha256})
+	}
+	if sbomPackage.md5 != "" {
+		component.Hashes = append(component.Hashes, CycloneDxHash{Alg: "MD5", Content: sbomPackage.md5})
+	}
+	// The build-info scopes are specific to each build tool, so they are kept as properties rather than mapped to the CycloneDX scopes.
+	for _, scope := range sbomPackage.scopes {
+		component.Properties = append(component.Properties, CycloneDxProperty{Name: "build-info:scope", Value: scope})
+	}
+	return component
+}
@@ -0,0 +1,238 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// The build-info doesn't hold the type of its modules, so it is detected from the way each build tool populates the module.
+const (
+	Maven   = "maven"
+	Npm     = "npm"
+	Nuget   = "nuget"
+	Go      = "golang"
+	Docker  = "docker"
+	Generic = "generic"
+)
+
+const dockerImageIdProperty = "docker.image.id"
+
+var (
+	// npm dependencies are recorded by their package file names, in the form of <name>-<version>.tgz
+	npmPackageFileRegExp = regexp.MustCompile(`^(.+)-(\d+\.\d+\.\d+.*)\.tgz$`)
+	// Go dependencies are recorded in the form of <module path>:<version>
+	goDependencyIdRegExp = regexp.MustCompile(`^[^:]+/[^:]+:v\d`)
+	mavenExtensions      = []string{".jar", ".pom", ".war", ".ear", ".aar"}
+)
+
+func detectModuleType(module utils.Module) string {
+	if hasProperty(module.Properties, dockerImageIdProperty) {
+		return Docker
+	}
+	names := moduleFileNames(module)
+	for _, name := range names {
+		if strings.HasSuffix(name, ".tgz") {
+			return Npm
+		}
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, ".nupkg") {
+			return Nuget
+		}
+	}
+	for _, dependency := range module.Dependencies {
+		if goDependencyIdRegExp.MatchString(dependency.Id) {
+			return Go
+		}
+	}
+	for _, artifact := range module.Artifacts {
+		if strings.HasSuffix(artifact.Name, ".mod") {
+			return Go
+		}
+	}
+	if len(strings.Split(module.Id, ":")) >= 3 {
+		return Maven
+	}
+	for _, name := range names {
+		if hasMavenExtension(name) {
+			return Maven
+		}
+	}
+	// NuGet projects are recorded by their names, and their dependencies in the form of <package name>:<version>
+	if !strings.Contains(module.Id, ":") && len(module.Dependencies) > 0 {
+		for _, dependency := range module.Dependencies {
+			if len(strings.Split(dependency.Id, ":")) != 2 {
+				return Generic
+			}
+		}
+		return Nuget
+	}
+	return Generic
+}
+
+// Returns the group, name, version and purl of the module, according to its type.
+func modulePackage(moduleType, moduleId string) (group, name, version, purl string) {
+	switch moduleType {
+	case Maven:
+		group, name, version = splitMavenId(moduleId)
+		return group, name, version, createPurl(Maven, group, name, version, "")
+	case Npm:
+		// npm modules are recorded in the form of [<scope>:]<name>:<version>
+		parts := strings.Split(moduleId, ":")
+		if len(parts) == 3 {
+			group = "@" + parts[0]
+		}
+		if len(parts) > 1 {
+			name, version = parts[len(parts)-2], parts[len(parts)-1]
+			return group, name, version, createPurl(Npm, group, name, version, "")
+		}
+	case Go:
+		return "", moduleId, "", createPurl(Go, "", moduleId, "", "")
+	case Nuget:
+		return "", moduleId, "", createPurl(Nuget, "", moduleId, "", "")
+	case Docker:
+		name, version, repositoryUrl := splitDockerImage(moduleId)
+		qualifiers := ""
+		if repositoryUrl != "" {
+			qualifiers = "repository_url=" + url.QueryEscape(repositoryUrl)
+		}
+		return "", name, version, createPurl(Docker, "", name, version, qualifiers)
+	}
+	return "", moduleId, "", createPurl(Generic, "", moduleId, "", "")
+}
+
+// Returns the group, name, version and purl of a dependency of a module of the given type.
+func dependencyPackage(moduleType string, dependency buildinfo.Dependency) (group, name, version, purl string) {
+	switch moduleType {
+	case Maven:
+		if len(strings.Split(dependency.Id, ":")) >= 3 {
+			group, name, version = splitMavenId(dependency.Id)
+			return group, name, version, createPurl(Maven, group, name, version, "")
+		}
+	case Npm:
+		if match := npmPackageFileRegExp.FindStringSubmatch(dependency.Id); match != nil {
+			return "", match[1], match[2], createPurl(Npm, "", match[1], match[2], "")
+		}
+	case Go, Nuget:
+		if separator := strings.LastIndex(dependency.Id, ":"); separator > 0 {
+			name, version = dependency.Id[:separator], dependency.Id[separator+1:]
+			return "", name, version, createPurl(moduleType, "", name, version, "")
+		}
+	}
+	return "", dependency.Id, "", createFilePurl(dependency.Id, dependency.Checksum)
+}
+
+// Returns the purl of an artifact of a module. Artifacts of Maven and npm modules are the module packages, other artifacts are described as files.
+func artifactPurl(moduleType, moduleId string, artifact utils.Artifact) string {
+	switch moduleType {
+	case Maven:
+		if extension := path.Ext(artifact.Name); hasMavenExtension(artifact.Name) {
+			group, name, version := splitMavenId(moduleId)
+			return createPurl(Maven, group, name, version, "type="+strings.TrimPrefix(extension, "."))
+		}
+	case Npm:
+		_, _, _, purl := modulePackage(Npm, moduleId)
+		return purl
+	}
+	return createFilePurl(artifact.Name, artifact.Checksum)
+}
+
+// Creates a package URL, as defined in https://github.com/package-url/purl-spec
+func createPurl(purlType, namespace, name, version, qualifiers string) string {
+	purl := "pkg:" + purlType + "/"
+	if namespace != "" {
+		purl += escapePurlPath(namespace) + "/"
+	}
+	purl += escapePurlPath(name)
+	if version != "" {
+		purl += "@" + escapePurlSegment(version)
+	}
+	if qualifiers != "" {
+		purl += "?" + qualifiers
+	}
+	return purl
+}
+
+// Files which aren't packages are identified by their names and checksums.
+func createFilePurl(name string, checksum *buildinfo.Checksum) string {
+	qualifiers := ""
+	if checksum != nil && checksum.Sha1 != "" {
+		qualifiers = "checksum=sha1:" + checksum.Sha1
+	}
+	return createPurl(Generic, "", path.Base(name), "", qualifiers)
+}
+
+// Escapes each segment of a purl namespace or name, keeping the segments separator.
+func escapePurlPath(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = escapePurlSegment(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// The '@' character separates the purl version, so it must be escaped in the other components.
+func escapePurlSegment(segment string) string {
+	return strings.Replace(url.PathEscape(segment), "@", "%40", -1)
+}
+
+// Splits a Maven ID in the form of <group>:<artifact>:[<type>:[<classifier>:]]<version>
+func splitMavenId(id string) (group, name, version string) {
+	parts := strings.Split(id, ":")
+	if len(parts) < 3 {
+		return "", id, ""
+	}
+	return parts[0], parts[1], parts[len(parts)-1]
+}
+
+// Splits a Docker image in the form of [<registry>/]<name>[:<tag>]
+func splitDockerImage(image string) (name, tag, registry string) {
+	name = image
+	if separator := strings.LastIndex(name, ":"); separator > strings.LastIndex(name, "/") {
+		name, tag = name[:separator], name[separator+1:]
+	}
+	if separator := strings.Index(name, "/"); separator > 0 {
+		firstSegment := name[:separator]
+		if strings.ContainsAny(firstSegment, ".:") || firstSegment == "localhost" {
+			registry, name = firstSegment, name[separator+1:]
+		}
+	}
+	return
+}
+
+func hasMavenExtension(name string) bool {
+	for _, extension := range mavenExtensions {
+		if strings.HasSuffix(name, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+func moduleFileNames(module utils.Module) []string {
+	var names []string
+	for _, artifact := range module.Artifacts {
+		names = append(names, artifact.Name)
+	}
+	for _, dependency := range module.Dependencies {
+		names = append(names, dependency.Id)
+	}
+	return names
+}
+
+// The module properties are a map of strings when created locally, and a map of interfaces when read from JSON.
+func hasProperty(properties interface{}, key string) bool {
+	switch typedProperties := properties.(type) {
+	case map[string]string:
+		_, ok := typedProperties[key]
+		return ok
+	case map[string]interface{}:
+		_, ok := typedProperties[key]
+		return ok
+	}
+	return false
+}
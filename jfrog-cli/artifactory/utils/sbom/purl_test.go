@@ -0,0 +1,57 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"testing"
+)
+
+func TestDetectModuleType(t *testing.T) {
+	tests := []struct {
+		name     string
+		module   buildinfo.Module
+		expected string
+	}{
+		{"maven", buildinfo.Module{Id: "org.jfrog:app:1.0", Artifacts: []buildinfo.Artifact{{Name: "app-1.0.jar"}}}, Maven},
+		{"npm", buildinfo.Module{Id: "app:1.0.0", Dependencies: []buildinfo.Dependency{{Id: "left-pad-1.3.0.tgz"}}}, Npm},
+		{"go", buildinfo.Module{Id: "github.com/jfrog/app", Dependencies: []buildinfo.Dependency{{Id: "github.com/pkg/errors:v0.8.0"}}}, Go},
+		{"nuget", buildinfo.Module{Id: "app", Dependencies: []buildinfo.Dependency{{Id: "Newtonsoft.Json:11.0.2"}}}, Nuget},
+		{"docker", buildinfo.Module{Id: "docker.io/app:1.0", Properties: map[string]interface{}{"docker.image.id": "sha256:1"}}, Docker},
+		{"generic", buildinfo.Module{Id: "my-build", Artifacts: []buildinfo.Artifact{{Name: "a.txt"}}}, Generic},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if moduleType := detectModuleType(utils.Module{Module: test.module, Artifacts: utils.NewArtifacts(test.module.Artifacts), Dependencies: utils.NewDependencies(test.module.Dependencies)}); moduleType != test.expected {
+				t.Error("Expected", test.expected, "got", moduleType)
+			}
+		})
+	}
+}
+
+func TestPurls(t *testing.T) {
+	tests := []struct {
+		moduleType string
+		moduleId   string
+		dependency buildinfo.Dependency
+		module     string
+		expected   string
+	}{
+		{Maven, "org.jfrog:app:1.0", buildinfo.Dependency{Id: "junit:junit:4.12"}, "pkg:maven/org.jfrog/app@1.0", "pkg:maven/junit/junit@4.12"},
+		{Npm, "jfrog:app:1.0.0", buildinfo.Dependency{Id: "utf-8-validate-5.0.2.tgz"}, "pkg:npm/%40jfrog/app@1.0.0", "pkg:npm/utf-8-validate@5.0.2"},
+		{Go, "github.com/jfrog/app", buildinfo.Dependency{Id: "github.com/pkg/errors:v0.8.0"}, "pkg:golang/github.com/jfrog/app", "pkg:golang/github.com/pkg/errors@v0.8.0"},
+		{Nuget, "app", buildinfo.Dependency{Id: "Newtonsoft.Json:11.0.2"}, "pkg:nuget/app", "pkg:nuget/Newtonsoft.Json@11.0.2"},
+		{Docker, "my.registry.io/team/app:1.0", buildinfo.Dependency{Id: "sha256__1", Checksum: &buildinfo.Checksum{Sha1: "abc"}},
+			"pkg:docker/team/app@1.0?repository_url=my.registry.io", "pkg:generic/sha256__1?checksum=sha1:abc"},
+		{Generic, "my-build", buildinfo.Dependency{Id: "libs/a b.zip"}, "pkg:generic/my-build", "pkg:generic/a%20b.zip"},
+	}
+	for _, test := range tests {
+		t.Run(test.moduleType, func(t *testing.T) {
+			if _, _, _, purl := modulePackage(test.moduleType, test.moduleId); purl != test.module {
+				t.Error("Expected module purl", test.module, "got", purl)
+			}
+			if _, _, _, purl := dependencyPackage(test.moduleType, test.dependency); purl != test.expected {
+				t.Error("Expected dependency purl", test.expected, "got", purl)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"time"
+)
+
+const (
+	SpdxJson      = "spdx-json"
+	CycloneDxJson = "cyclonedx-json"
+)
+
+// Converts the build-info to an SBOM document in the requested format.
+// The returned document should be marshaled to JSON.
+func Create(buildInfo *utils.BuildInfo, format string) (interface{}, error) {
+	serialNumber, err := createUuid()
+	if err != nil {
+		return nil, err
+	}
+	packages := collectPackages(buildInfo)
+	created := time.Now().UTC()
+	switch format {
+	case SpdxJson:
+		return createSpdx(buildInfo, packages, serialNumber, created), nil
+	case CycloneDxJson:
+		return createCycloneDx(buildInfo, packages, serialNumber, created), nil
+	}
+	return nil, errorutils.CheckError(errors.New("Unsupported SBOM format: " + format + ". Accepts '" + SpdxJson + "' or '" + CycloneDxJson + "'."))
+}
+
+// A package described by the SBOM, which is a build-info module, one of its artifacts or one of its dependencies.
+type sbomPackage struct {
+	ref     string
+	group   string
+	name    string
+	version string
+	purl    string
+	sha1    string
+	sha256  string
+	md5     string
+	scopes  []string
+}
+
+// The packages of a single module.
+// Dependencies may be shared by several modules, in which case the modules reference the same dependency packages.
+type modulePackages struct {
+	module       *sbomPackage
+	moduleType   string
+	artifacts    []*sbomPackage
+	dependencies []*sbomPackage
+}
+
+type buildPackages struct {
+	modules []*modulePackages
+	// The unique dependencies of all modules, by the order of their first appearance.
+	dependencies []*sbomPackage
+}
+
+func collectPackages(buildInfo *utils.BuildInfo) *buildPackages {
+	packages := new(buildPackages)
+	dependenciesByPurl := make(map[string]*sbomPackage)
+	for i, module := range buildInfo.Modules {
+		moduleType := detectModuleType(module)
+		group, name, version, purl := modulePackage(moduleType, module.Id)
+		current := &modulePackages{
+			module:     &sbomPackage{ref: fmt.Sprintf("module-%d", i+1), group: group, name: name, version: version, purl: purl},
+			moduleType: moduleType,
+		}
+		for j, artifact := range module.Artifacts {
+			artifactPackage := &sbomPackage{ref: fmt.Sprintf("artifact-%d-%d", i+1, j+1), name: artifact.Name, purl: artifactPurl(moduleType, module.Id, artifact)}
+			setChecksums(artifactPackage, artifact.Checksum)
+			artifactPackage.sha256 = artifact.Sha256
+			current.artifacts = append(current.artifacts, artifactPackage)
+		}
+		dependsOn := make(map[string]bool)
+		for _, dependency := range module.Dependencies {
+			group, name, version, purl := dependencyPackage(moduleType, dependency.Dependency)
+			dependencyPackage, exists := dependenciesByPurl[purl]
+			if !exists {
+				dependencyPackage = &sbomPackage{ref: fmt.Sprintf("dependency-%d", len(packages.dependencies)+1), group: group, name: name, version: version, purl: purl, scopes: dependency.Scopes}
+				setChecksums(dependencyPackage, dependency.Checksum)
+				dependenciesByPurl[purl] = dependencyPackage
+				packages.dependencies = append(packages.dependencies, dependencyPackage)
+			}
+			if !dependsOn[purl] {
+				dependsOn[purl] = true
+				current.dependencies = append(current.dependencies, dependencyPackage)
+			}
+		}
+		packages.modules = append(packages.modules, current)
+	}
+	return packages
+}
+
+func setChecksums(sbomPackage *sbomPackage, checksum *buildinfo.Checksum) {
+	if checksum != nil {
+		sbomPackage.sha1 = checksum.Sha1
+		sbomPackage.md5 = checksum.Md5
+	}
+}
+
+func getToolName() string {
+	return cliutils.ClientAgent + "-" + cliutils.GetVersion()
+}
+
+// Creates a random (version 4) UUID.
+func createUuid() (string, error) {
+	uuid := make([]byte, 16)
+	if _, err := rand.Read(uuid); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
+}
@@ -0,0 +1,84 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"testing"
+)
+
+var testBuildInfo = utils.ConvertBuildInfo(&buildinfo.BuildInfo{
+	Name:   "build",
+	Number: "1",
+	Modules: []buildinfo.Module{
+		{
+			Id:           "org.jfrog:app:1.0",
+			Artifacts:    []buildinfo.Artifact{{Name: "app-1.0.jar", Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}}},
+			Dependencies: []buildinfo.Dependency{{Id: "junit:junit:4.12", Scopes: []string{"test"}, Checksum: &buildinfo.Checksum{Sha1: "3"}}},
+		},
+		{
+			Id:           "org.jfrog:lib:1.0",
+			Dependencies: []buildinfo.Dependency{{Id: "junit:junit:4.12", Checksum: &buildinfo.Checksum{Sha1: "3"}}},
+		},
+	},
+})
+
+func TestCreateSpdx(t *testing.T) {
+	document, err := Create(testBuildInfo, SpdxJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spdx := document.(*SpdxDocument)
+	if spdx.SpdxVersion != "SPDX-2.3" || spdx.Name != "build-1" {
+		t.Error("Unexpected document:", spdx)
+	}
+	// Two modules, one artifact and one dependency shared by both modules.
+	if len(spdx.Packages) != 4 {
+		t.Fatal("Expected 4 packages, got:", spdx.Packages)
+	}
+	dependency := spdx.Packages[3]
+	if dependency.ExternalRefs[0].ReferenceLocator != "pkg:maven/junit/junit@4.12" || dependency.Checksums[0].ChecksumValue != "3" {
+		t.Error("Unexpected dependency package:", dependency)
+	}
+	var dependsOn int
+	for _, relationship := range spdx.Relationships {
+		if relationship.RelationshipType == "DEPENDS_ON" {
+			dependsOn++
+			if relationship.RelatedSpdxElement != dependency.SpdxId {
+				t.Error("Unexpected relationship:", relationship)
+			}
+		}
+	}
+	if dependsOn != 2 {
+		t.Error("Expected both modules to depend on the shared dependency, got:", spdx.Relationships)
+	}
+}
+
+func TestCreateCycloneDx(t *testing.T) {
+	document, err := Create(testBuildInfo, CycloneDxJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cycloneDx := document.(*CycloneDxDocument)
+	if cycloneDx.BomFormat != "CycloneDX" || cycloneDx.Metadata.Component.Name != "build" {
+		t.Error("Unexpected document:", cycloneDx)
+	}
+	if len(cycloneDx.Components) != 3 {
+		t.Fatal("Expected 3 components, got:", cycloneDx.Components)
+	}
+	app := cycloneDx.Components[0]
+	if app.Purl != "pkg:maven/org.jfrog/app@1.0" || len(app.Components) != 1 || app.Components[0].Purl != "pkg:maven/org.jfrog/app@1.0?type=jar" {
+		t.Error("Unexpected module component:", app)
+	}
+	junit := cycloneDx.Components[2]
+	if junit.Group != "junit" || junit.Version != "4.12" || len(junit.Properties) != 1 || junit.Properties[0].Value != "test" {
+		t.Error("Unexpected dependency component:", junit)
+	}
+	// The build, two modules and one dependency.
+	if len(cycloneDx.Dependencies) != 4 || len(cycloneDx.Dependencies[0].DependsOn) != 2 {
+		t.Error("Unexpected dependencies graph:", cycloneDx.Dependencies)
+	}
+
+	if _, err = Create(testBuildInfo, "unknown"); err == nil {
+		t.Error("Expected an error for an unsupported format.")
+	}
+}
@@ -0,0 +1,123 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	"regexp"
+	"time"
+)
+
+// The SPDX 2.3 JSON document, as defined in https://spdx.github.io/spdx-spec/v2.3/
+type SpdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SpdxId            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SpdxCreationInfo   `json:"creationInfo"`
+	Packages          []SpdxPackage      `json:"packages"`
+	Relationships     []SpdxRelationship `json:"relationships"`
+}
+
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SpdxPackage struct {
+	Name             string            `json:"name"`
+	SpdxId           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	Checksums        []SpdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []SpdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type SpdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SpdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+const (
+	spdxDocumentId  = "SPDXRef-DOCUMENT"
+	spdxNoAssertion = "NOASSERTION"
+)
+
+var spdxIdInvalidCharsRegExp = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+func createSpdx(buildInfo *utils.BuildInfo, packages *buildPackages, uuid string, created time.Time) *SpdxDocument {
+	document := &SpdxDocument{
+		SpdxVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SpdxId:            spdxDocumentId,
+		Name:              buildInfo.Name + "-" + buildInfo.Number,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + spdxIdInvalidCharsRegExp.ReplaceAllString(buildInfo.Name+"-"+buildInfo.Number, "-") + "-" + uuid,
+		CreationInfo:      SpdxCreationInfo{Created: created.Format(time.RFC3339), Creators: []string{"Tool: " + getToolName()}},
+		Packages:          []SpdxPackage{},
+		Relationships:     []SpdxRelationship{},
+	}
+	for _, module := range packages.modules {
+		moduleId := toSpdxId(module.module)
+		document.Packages = append(document.Packages, toSpdxPackage(module.module, "APPLICATION"))
+		document.Relationships = append(document.Relationships, SpdxRelationship{SpdxElementId: spdxDocumentId, RelationshipType: "DESCRIBES", RelatedSpdxElement: moduleId})
+		for _, artifact := range module.artifacts {
+			document.Packages = append(document.Packages, toSpdxPackage(artifact, "FILE"))
+			document.Relationships = append(document.Relationships, SpdxRelationship{SpdxElementId: moduleId, RelationshipType: "GENERATES", RelatedSpdxElement: toSpdxId(artifact)})
+		}
+		for _, dependency := range module.dependencies {
+			document.Relationships = append(document.Relationships, SpdxRelationship{SpdxElementId: moduleId, RelationshipType: "DEPENDS_ON", RelatedSpdxElement: toSpdxId(dependency)})
+		}
+	}
+	for _, dependency := range packages.dependencies {
+		document.Packages = append(document.Packages, toSpdxPackage(dependency, "LIBRARY"))
+	}
+	return document
+}
+
+func toSpdxPackage(sbomPackage *sbomPackage, purpose string) SpdxPackage {
+	name := sbomPackage.name
+	if sbomPackage.group != "" {
+		name = sbomPackage.group + "/" + name
+	}
+	spdxPackage := SpdxPackage{
+		Name:             name,
+		SpdxId:           toSpdxId(sbomPackage),
+		VersionInfo:      sbomPackage.version,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+		PrimaryPurpose:   purpose,
+		ExternalRefs:     []SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: sbomPackage.purl}},
+	}
+	if sbomPackage.sha1 != "" {
+		spdxPackage.Checksums = append(spdxPackage.Checksums, SpdxChecksum{Algorithm: "SHA1", ChecksumValue: sbomPackage.sha1})
+	}
+	if sbomPackage.sha256 != "" {
+		spdxPackage.Checksums = append(spdxPackage.Checksums, SpdxChecksum{Algorithm: "SHA256", ChecksumValue: sbomPackage.sha256})
+	}
+	if sbomPackage.md5 != "" {
+		spdxPackage.Checksums = append(spdxPackage.Checksums, SpdxChecksum{Algorithm: "MD5", ChecksumValue: sbomPackage.md5})
+	}
+	return spdxPackage
+}
+
+func toSpdxId(sbomPackage *sbomPackage) string {
+	return "SPDXRef-" + sbomPackage.ref
+}
@@ -0,0 +1,167 @@
+package secrets
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Replaces the values which may contain secrets.
+const MaskedValue = "*****"
+
+// The minimal length and entropy (in bits per character) of a value which looks like a random token.
+const (
+	minTokenLength  = 24
+	minTokenEntropy = 4.0
+)
+
+// A variable whose value was masked, and the reason it was suspected to contain a secret.
+type MaskedVariable struct {
+	Key    string
+	Reason string
+}
+
+// Words which indicate that the variable holds a secret, when they appear as a separate word in its name.
+var secretKeyWords = map[string]bool{
+	"password":    true,
+	"passwd":      true,
+	"pass":        true,
+	"secret":      true,
+	"secrets":     true,
+	"token":       true,
+	"tokens":      true,
+	"credential":  true,
+	"credentials": true,
+	"apikey":      true,
+	"auth":        true,
+	"passphrase":  true,
+}
+
+// Words which indicate that the variable holds a secret, when they appear right before the word "key" in its name.
+var secretKeyQualifiers = map[string]bool{
+	"api":        true,
+	"access":     true,
+	"private":    true,
+	"secret":     true,
+	"signing":    true,
+	"encryption": true,
+	"master":     true,
+}
+
+var secretFormats = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)},
+	{"Artifactory API key", regexp.MustCompile(`\bAKC[A-Za-z0-9]{10,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"URL credentials", regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`)},
+}
+
+var tokenCharacters = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+
+// Masks the values of the variables which may contain secrets, and returns the masked variables sorted by their keys.
+func Mask(variables map[string]string) []MaskedVariable {
+	var masked []MaskedVariable
+	for key, value := range variables {
+		if reason := Detect(key, value); reason != "" {
+			variables[key] = MaskedValue
+			masked = append(masked, MaskedVariable{Key: key, Reason: reason})
+		}
+	}
+	sort.Slice(masked, func(i, j int) bool {
+		return masked[i].Key < masked[j].Key
+	})
+	return masked
+}
+
+// Returns the reason the variable is suspected to contain a secret, or an empty string if it isn't.
+func Detect(key, value string) string {
+	if value == "" || value == MaskedValue {
+		return ""
+	}
+	if isSecretKey(key) {
+		return "secret name"
+	}
+	for _, format := range secretFormats {
+		if format.pattern.MatchString(value) {
+			return format.name + " format"
+		}
+	}
+	if isRandomToken(value) {
+		return "high entropy value"
+	}
+	return ""
+}
+
+func isSecretKey(key string) bool {
+	words := splitKeyWords(key)
+	for i, word := range words {
+		if secretKeyWords[word] {
+			return true
+		}
+		if word == "key" && i > 0 && secretKeyQualifiers[words[i-1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits a key such as "buildInfo.env.AWS_SECRET_ACCESS_KEY" or "githubToken" into lower case words.
+func splitKeyWords(key string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(key)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			words, current = appendWord(words, current), nil
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]) {
+			words, current = appendWord(words, current), nil
+		}
+		current = append(current, r)
+	}
+	return appendWord(words, current)
+}
+
+func appendWord(words []string, word []rune) []string {
+	if len(word) == 0 {
+		return words
+	}
+	return append(words, strings.ToLower(string(word)))
+}
+
+// Values which look like random tokens contain upper case letters, lower case letters and digits, and have a high entropy.
+// Commit hashes and UUIDs don't contain upper case letters, and therefore aren't considered tokens.
+func isRandomToken(value string) bool {
+	if len(value) < minTokenLength || !tokenCharacters.MatchString(value) {
+		return false
+	}
+	hasUpper, hasLower, hasDigit := false, false, false
+	for _, r := range value {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+		hasDigit = hasDigit || unicode.IsDigit(r)
+	}
+	return hasUpper && hasLower && hasDigit && entropy(value) >= minTokenEntropy
+}
+
+// Returns the Shannon entropy of the value, in bits per character.
+func entropy(value string) float64 {
+	frequencies := make(map[rune]float64)
+	for _, r := range value {
+		frequencies[r]++
+	}
+	length := float64(len([]rune(value)))
+	result := 0.0
+	for _, frequency := range frequencies {
+		probability := frequency / length
+		result -= probability * math.Log2(probability)
+	}
+	return result
+}
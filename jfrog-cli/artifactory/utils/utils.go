@@ -2,14 +2,17 @@ package utils
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth/cert"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/httpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"io"
@@ -21,9 +24,14 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 )
 
 const repoDetailsUrl = "api/repositories/"
+const buildInfoUrl = "api/build/"
+
+// The format of the time the build started, in the build-info.
+const BuildStartedFormat = "2006-01-02T15:04:05.000-0700"
 
 func GetJfrogSecurityDir() (string, error) {
 	homeDir, err := config.GetJfrogHomeDir()
@@ -64,12 +72,143 @@ func GetEncryptedPasswordFromArtifactory(artifactoryAuth auth.ArtifactoryDetails
 	return "", errorutils.CheckError(errors.New("Artifactory response: " + resp.Status))
 }
 
+// Returns the build-info of a build published to Artifactory.
+func GetPublishedBuildInfo(buildName, buildNumber string, artifactoryAuth auth.ArtifactoryDetails) (*BuildInfo, error) {
+	// The build name and number may include slashes, which are escaped rather than separating the path.
+	buildUrl := clientutils.AddTrailingSlashIfNeeded(artifactoryAuth.GetUrl()) + buildInfoUrl + url.PathEscape(buildName) + "/" + url.PathEscape(buildNumber)
+	httpClientsDetails := artifactoryAuth.CreateHttpClientDetails()
+	client, err := createHttpClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, body, _, err := client.SendGet(buildUrl, true, httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckError(errors.New("Build " + buildName + "/" + buildNumber + " was not found in Artifactory."))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + string(body)))
+	}
+
+	publishedBuildInfo := new(struct {
+		BuildInfo *BuildInfo `json:"buildInfo,omitempty"`
+	})
+	if err = json.Unmarshal(body, publishedBuildInfo); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if publishedBuildInfo.BuildInfo == nil {
+		return nil, errorutils.CheckError(errors.New("Unexpected build-info response from Artifactory: " + string(body)))
+	}
+	return publishedBuildInfo.BuildInfo, nil
+}
+
+// Returns the number of the build which was started last among the builds published to Artifactory, before the received start time, excluding the received build number.
+// Returns an empty string if no such build was published.
+func GetPreviousBuildNumber(buildName, buildNumber string, started time.Time, artifactoryAuth auth.ArtifactoryDetails) (string, error) {
+	buildUrl := clientutils.AddTrailingSlashIfNeeded(artifactoryAuth.GetUrl()) + buildInfoUrl + url.PathEscape(buildName)
+	httpClientsDetails := artifactoryAuth.CreateHttpClientDetails()
+	client, err := createHttpClient()
+	if err != nil {
+		return "", err
+	}
+	resp, body, _, err := client.SendGet(buildUrl, true, httpClientsDetails)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + string(body)))
+	}
+
+	builds := new(publishedBuilds)
+	if err = json.Unmarshal(body, builds); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return builds.getPreviousBuildNumber(buildNumber, started), nil
+}
+
+// The builds of a build name, as returned by Artifactory.
+type publishedBuilds struct {
+	BuildsNumbers []struct {
+		Uri     string `json:"uri"`
+		Started string `json:"started"`
+	} `json:"buildsNumbers"`
+}
+
+// Builds may be published in a different order than the order they were started in, such as when an older build is published later.
+// Therefore, the previous build is the last one started before the received start time.
+func (builds *publishedBuilds) getPreviousBuildNumber(buildNumber string, started time.Time) string {
+	previousBuildNumber, previousBuildStarted := "", time.Time{}
+	for _, build := range builds.BuildsNumbers {
+		number, err := url.PathUnescape(strings.TrimPrefix(build.Uri, "/"))
+		if err != nil || number == buildNumber {
+			continue
+		}
+		buildStarted, err := time.Parse(BuildStartedFormat, build.Started)
+		if err != nil || !buildStarted.Before(started) {
+			continue
+		}
+		if previousBuildNumber == "" || buildStarted.After(previousBuildStarted) {
+			previousBuildNumber, previousBuildStarted = number, buildStarted
+		}
+	}
+	return previousBuildNumber
+}
+
+// Publishes the build-info to Artifactory. In dry-run, the build-info is only printed.
+// The publish of the client accepts only the client build-info, which would drop the extended details of the build-info.
+// Therefore, the build-info is published by the CLI, with the same request the client sends.
+func PublishBuildInfo(buildInfo *BuildInfo, artifactoryAuth auth.ArtifactoryDetails, dryRun bool) error {
+	content, err := json.Marshal(buildInfo)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if dryRun {
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	httpClientsDetails := artifactoryAuth.CreateHttpClientDetails()
+	servicesutils.SetContentType("application/vnd.org.jfrog.artifactory+json", &httpClientsDetails.Headers)
+	client, err := createHttpClient()
+	if err != nil {
+		return err
+	}
+	log.Info("Deploying build info...")
+	resp, body, err := client.SendPut(artifactoryAuth.GetUrl()+buildInfoUrl, content, httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	log.Info("Build info successfully deployed. Browse it in Artifactory under " + artifactoryAuth.GetUrl() + "webapp/builds/" + buildInfo.Name + "/" + buildInfo.Number)
+	return nil
+}
+
+// Creates an HTTP client, which trusts the certificates in the JFrog security directory.
+func createHttpClient() (*httpclient.HttpClient, error) {
+	securityDir, err := GetJfrogSecurityDir()
+	if err != nil {
+		return nil, err
+	}
+	transport, err := cert.GetTransportWithLoadedCert(securityDir)
+	if err != nil {
+		return nil, err
+	}
+	return httpclient.NewHttpClient(&http.Client{Transport: transport}), nil
+}
+
 func CreateServiceManager(artDetails *config.ArtifactoryDetails, isDryRun bool) (*artifactory.ArtifactoryServicesManager, error) {
 	certPath, err := GetJfrogSecurityDir()
 	if err != nil {
 		return nil, err
 	}
-	artAuth, err := artDetails.CreateArtAuthConfig()
+	artAuth, err := artDetails.CreateArtAuthConfig(lock.LockConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"encoding/json"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetHomeDir(t *testing.T) {
@@ -84,3 +86,38 @@ func TestReturnErrorOnNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPreviousBuildNumber(t *testing.T) {
+	content := `{"buildsNumbers": [
+		{"uri": "/1", "started": "2018-10-01T10:00:00.000+0000"},
+		{"uri": "/3", "started": "2018-10-03T10:00:00.000+0000"},
+		{"uri": "/2", "started": "2018-10-02T10:00:00.000+0000"},
+		{"uri": "/4", "started": "2018-10-04T10:00:00.000+0000"}
+	]}`
+	builds := new(publishedBuilds)
+	if err := json.Unmarshal([]byte(content), builds); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		buildNumber string
+		started     string
+		expected    string
+	}{
+		{"latest", "5", "2018-10-05T10:00:00.000+0000", "4"},
+		{"published later", "3", "2018-10-03T10:00:00.000+0000", "2"},
+		{"started before published builds", "5", "2018-09-30T10:00:00.000+0000", ""},
+		{"republished", "4", "2018-10-06T10:00:00.000+0000", "3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			started, err := time.Parse(BuildStartedFormat, test.started)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if previous := builds.getPreviousBuildNumber(test.buildNumber, started); previous != test.expected {
+				t.Error("Expected previous build", test.expected+", got", previous)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/config/decrypt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/config/encrypt"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:      "encrypt",
+			Usage:     encrypt.Description,
+			HelpName:  common.CreateUsage("config encrypt", encrypt.Description, encrypt.Usage),
+			UsageText: encrypt.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				encryptCmd(c)
+			},
+		},
+		{
+			Name:      "decrypt",
+			Usage:     decrypt.Description,
+			HelpName:  common.CreateUsage("config decrypt", decrypt.Description, decrypt.Usage),
+			UsageText: decrypt.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				decryptCmd(c)
+			},
+		},
+	}
+}
+
+func encryptCmd(c *cli.Context) {
+	if c.NArg() != 0 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	cliutils.ExitOnErr(config.EncryptConfig())
+	log.Info("The configuration was encrypted.")
+}
+
+func decryptCmd(c *cli.Context) {
+	if c.NArg() != 0 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	cliutils.ExitOnErr(config.DecryptConfig())
+	log.Info("The configuration was decrypted.")
+}
@@ -1,6 +1,6 @@
 package buildadddependencies
 
-const Description = "Adds dependencies from the local file-system to the build info."
+const Description = "Adds dependencies from the local file-system or from Artifactory to the build info."
 
 var Usage = []string{"jfrog rt bad [command options] <build name> <build number> <pattern>",
 	"jfrog rt bad --spec=<File Spec path> [command options] <build name> <build number>"}
@@ -13,5 +13,6 @@ const Arguments string = `	build name
 
 	pattern
 		Specifies the local file system path to dependencies which should be added to the build info.
+		When the --from-rt option is set, specifies the path in Artifactory, in the following format: [repository name]/[repository path].
 		You can specify multiple dependencies by using wildcards or a regular expression as designated by the --regexp command option.
 		If you have specified that you are using regular expressions, then the first one used in the argument must be enclosed in parenthesis.`
@@ -1,6 +1,6 @@
 package buildaddgit
 
-const Description = "Capture git revision and remote url."
+const Description = "Capture git revision, remote url, branch, commit message and author, working tree status and submodules."
 
 var Usage = []string{"jfrog rt bag [command options] <build name> <build number> [Path To .git]"}
 
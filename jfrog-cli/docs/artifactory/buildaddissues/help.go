@@ -0,0 +1,14 @@
+package buildaddissues
+
+const Description = "Collect the issues referred to by the git commit messages since the previous build published to Artifactory."
+
+var Usage = []string{"jfrog rt bai [command options] <build name> <build number> [Path To .git]"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	path to .git
+		Path to a directory containing the .git directory. If not specific, the .git directory is assumed to be in the current directory.`
@@ -0,0 +1,11 @@
+package buildcollectci
+
+const Description = "Collect the build URL, principal, VCS and job details from the CI provider which runs the build. Supports Jenkins, GitLab CI, GitHub Actions, Azure Pipelines, CircleCI and TeamCity."
+
+var Usage = []string{"jfrog rt bci <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.`
@@ -0,0 +1,15 @@
+package builddiff
+
+const Description = "Compare two builds."
+
+var Usage = []string{"jfrog rt bdf [command options] <build name> <build number A> <build number B>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number A
+		The build number to compare from.
+
+	build number B
+		The build number to compare to.
+		Each build is read from the build-info collected locally if exists, or from the build-info published to Artifactory otherwise.`
@@ -0,0 +1,14 @@
+package buildexport
+
+const Description = "Export the build info collected locally to a file, which can be published later using the build-import command."
+
+var Usage = []string{"jfrog rt be [command options] <build name> <build number> <target path>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	target path
+		Path of the build info file to create.`
@@ -0,0 +1,8 @@
+package buildimport
+
+const Description = "Publish a build info file created by the build-export command."
+
+var Usage = []string{"jfrog rt bi [command options] <file path>"}
+
+const Arguments string = `	file path
+		Path of the build info file to publish.`
@@ -0,0 +1,5 @@
+package buildlist
+
+const Description = "List the builds collected locally, which were not published yet."
+
+var Usage = []string{"jfrog rt bl"}
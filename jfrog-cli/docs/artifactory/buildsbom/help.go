@@ -0,0 +1,12 @@
+package buildsbom
+
+const Description = "Create an SBOM (software bill of materials) of a build."
+
+var Usage = []string{"jfrog rt bsb [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+		The build is read from the build info collected locally if exists, or from the build info published to Artifactory otherwise.`
@@ -0,0 +1,5 @@
+package buildstoreconfig
+
+const Description = "Configure the location and expiry of the build info collected locally."
+
+var Usage = []string{"jfrog rt bsc [command options]"}
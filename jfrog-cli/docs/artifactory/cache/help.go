@@ -0,0 +1,16 @@
+package cache
+
+const Description = "Manage the local download cache."
+
+var Usage = []string{"jfrog rt cache clean",
+	"jfrog rt cache stats"}
+
+const Arguments string = `	clean
+		Removes all files from the local download cache.
+
+	stats
+		Shows the local download cache location, number of files, total size and max size.`
+
+const EnvVar string = `	JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB
+		[Default: 10240]
+		Max size in MB of the local download cache. When exceeded, the least recently used files are removed from the cache.`
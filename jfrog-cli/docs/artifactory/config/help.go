@@ -5,7 +5,9 @@ const Description string = "Configure Artifactory details."
 var Usage = []string{"jfrog rt c [command options] [server ID]",
 	"jfrog rt c show [server ID]",
 	"jfrog rt c [--interactive=<true|false>] delete <server ID>",
-	"jfrog rt c [--interactive=<true|false>] clear"}
+	"jfrog rt c [--interactive=<true|false>] clear",
+	"jfrog rt c [--passphrase=<passphrase>] export [server ID]",
+	"jfrog rt c [--passphrase=<passphrase>] [--overwrite=<true|false>] import <server token>"}
 
 const Arguments string = `	server ID
 		A unique ID for the new Artifactory server configuration.
@@ -18,4 +20,16 @@ const Arguments string = `	server ID
 		This argument should be followed by a configured server ID. The configuration for this server ID will be deleted.
 
 	clear
-		Clears all stored configuration.`
+		Clears all stored configuration.
+
+	export
+		Prints a token of the configured server, which can be imported by the import argument, for example on a CI agent.
+		In case this argument is followed by a configured server ID, then this server is exported. Otherwise, the default server is exported.
+		If the --passphrase option or the JFROG_CLI_SERVER_TOKEN_PASSPHRASE environment variable is set, the token is encrypted with the passphrase.
+		Otherwise, if the command is interactive, the passphrase is prompted for. Leave it empty to create a token which is not encrypted.
+		Servers which authenticate with an SSH key file cannot be exported, since the path of the key file is local to this machine.
+
+	import
+		This argument should be followed by a server token created by the export argument. The server of the token is added to the configuration.
+		If the token is encrypted, the passphrase it was encrypted with is read from the --passphrase option or the JFROG_CLI_SERVER_TOKEN_PASSPHRASE environment variable.
+		If neither is set and the command is interactive, the passphrase is prompted for.`
@@ -17,3 +17,8 @@ const Arguments string = `	source pattern
 		For example, if you specify the target as "a/b", the downloaded file is renamed to "b".
 		For flexibility in specifying the target path, you can include placeholders in the form of {1}, {2} which are replaced by corresponding
 		tokens in the source path that are enclosed in parenthesis.`
+
+const EnvVar string = `	JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB
+		[Default: 10240]
+		Max size in MB of the local download cache, used when the --cache option is set.
+		When exceeded, the least recently used files are removed from the cache.`
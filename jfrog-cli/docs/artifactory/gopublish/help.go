@@ -2,9 +2,10 @@ package gopublish
 
 const Description = "Publish go package and/or its dependencies to Artifactory"
 
-var Usage = []string{`jfrog rt gp [command options] <target repository> <project version>`}
+var Usage = []string{`jfrog rt gp [command options] [target repository] <project version>`}
 
 const Arguments string = `	target repository
 		Target repository in Artifactory.
+		If not set, the go deploy repository of the project configuration in .jfrog/project.yaml is used.
 	project version
 		Package version to be published.`
@@ -2,10 +2,12 @@ package gradle
 
 const Description = "Run Gradle build."
 
-var Usage = []string{`jfrog rt gradle "<tasks and options>" <config file path> [command options]`, `jfrog rt gradle "<tasks and options> -b path/to/build.gradle" <config file path> [command options]`}
+var Usage = []string{`jfrog rt gradle "<tasks and options>" [config file path] [command options]`, `jfrog rt gradle "<tasks and options> -b path/to/build.gradle" [config file path] [command options]`}
 
 const Arguments string = `	tasks and options
 		Tasks and options to run with gradle command.
 
 	config file path
-		Path to a configuration file generated by the "jfrog rt gradlec" command.`
+		Path to a configuration file generated by the "jfrog rt gradlec" command.
+		The gradle repositories of the project configuration in .jfrog/project.yaml are used if the configuration file doesn't set its own repositories.
+		If the project configuration sets the gradle repositories, the configuration file can be omitted.`
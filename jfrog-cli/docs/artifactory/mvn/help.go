@@ -2,10 +2,12 @@ package mvn
 
 const Description = "Run Maven build."
 
-var Usage = []string{`jfrog rt mvn "<goals and options>" <config file path> [command options]`, `jfrog rt mvn "<goals and options> -f path/to/pom.xml" <config file path> [command options]`}
+var Usage = []string{`jfrog rt mvn "<goals and options>" [config file path] [command options]`, `jfrog rt mvn "<goals and options> -f path/to/pom.xml" [config file path] [command options]`}
 
 const Arguments string = `	goals and options
 		Goals and options to run with mvn command.
 
 	config file path
-		Path to a configuration file generated by the "jfrog rt mvnc" command.`
+		Path to a configuration file generated by the "jfrog rt mvnc" command.
+		The maven repositories of the project configuration in .jfrog/project.yaml are used if the configuration file doesn't set its own repositories.
+		If the project configuration sets the maven repositories, the configuration file can be omitted.`
@@ -2,7 +2,8 @@ package npminstall
 
 const Description = "Run npm install."
 
-var Usage = []string{`jfrog rt npmi [command options] <repository name>`}
+var Usage = []string{`jfrog rt npmi [command options] [repository name]`}
 
 const Arguments string = `	repository name
-		The source npm repository. Can be a local, remote or virtual npm repository.`
+		The source npm repository. Can be a local, remote or virtual npm repository.
+		If not set, the npm resolve repository of the project configuration in .jfrog/project.yaml is used.`
@@ -2,10 +2,11 @@ package nuget
 
 const Description = "Run NuGet."
 
-var Usage = []string{`jfrog rt nuget [command options] <nuget args> <source repository name>`}
+var Usage = []string{`jfrog rt nuget [command options] <nuget args> [source repository name]`}
 
 const Arguments string = `	nuget command
 		The nuget command to run. For example, restore.
 
 	source repository name
-		The source NuGet repository. Can be a local, remote or virtual NuGet repository.`
+		The source NuGet repository. Can be a local, remote or virtual NuGet repository.
+		If not set, the nuget resolve repository of the project configuration in .jfrog/project.yaml is used.`
@@ -0,0 +1,9 @@
+package specvalidate
+
+const Description = "Validate a File Spec, reporting unknown fields, invalid values and fields the command doesn't support."
+
+var Usage = []string{"jfrog rt sv [command options] <File Spec path>",
+	"jfrog rt sv --schema"}
+
+const Arguments string = `	File Spec path
+		Path to a File Spec, in JSON or YAML format.`
@@ -0,0 +1,14 @@
+package syncdownload
+
+const Description = "Sync a local directory with a path in Artifactory."
+
+var Usage = []string{"jfrog rt sd [command options] <source path> <target dir>",
+	"jfrog rt sd --spec=<File Spec path> [command options]"}
+
+const Arguments string = `	source path
+		Specifies the source path in Artifactory in the following format: <repository name>/<repository path>.
+
+	target dir
+		Specifies the local file system directory to be synced with the source path.
+		Files which don't exist in the target directory, or exist with a different checksum, are downloaded.
+		If the --delete option is set, files under the target directory which don't exist in Artifactory are deleted.`
@@ -0,0 +1,14 @@
+package syncupload
+
+const Description = "Sync a local directory to a path in Artifactory."
+
+var Usage = []string{"jfrog rt su [command options] <source dir> <target path>",
+	"jfrog rt su --spec=<File Spec path> [command options]"}
+
+const Arguments string = `	source dir
+		Specifies the local file system directory to be synced to Artifactory.
+
+	target path
+		Specifies the target path in Artifactory in the following format: <repository name>/<repository path>.
+		Files which don't exist in the target path, or exist with a different checksum, are uploaded.
+		If the --delete option is set, files under the target path which don't exist in the source directory are deleted from Artifactory.`
@@ -16,4 +16,38 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 	JFROG_CLI_HOME_DIR
 		[Default: ~/.jfrog]
 		Defines the JFrog CLI home directory path.
+
+	JFROG_CLI_SUMMARY_FORMAT
+		[Optional]
+		Set to 'json' to print a detailed summary at the end of the upload, download, copy, move, delete, set-props,
+		build-publish and docker-push commands, including the source, target, checksums, size, duration and error
+		of each of the handled files, and the duration and error of the command.
+		The copy and move commands report the pattern and target of the spec groups which failed, rather than each of their files.
+
+	JFROG_CLI_BUILDS_DIR
+		[Default: ~/.jfrog/builds]
+		Defines the directory of the build info collected locally, which was not published yet.
+		Overrides the directory set by the build-store-config command.
+
+	JFROG_CLI_BUILDS_EXPIRY_DAYS
+		[Default: 14]
+		Build info collected locally, which was not updated for this number of days, is removed.
+		Set to 0 to keep the build info until it is published or cleaned.
+		Overrides the expiry set by the build-store-config command.
+
+	JFROG_CLI_ENCRYPTION_KEY
+		[Optional]
+		The master key, of at least 32 characters, which encrypts the passwords, API keys and SSH passphrases
+		in the JFrog CLI config file, once it is encrypted by the 'jfrog config encrypt' command.
+		The key is then required by the commands which use the server details, but not by the commands which only
+		use the build info settings, such as build-store-config and build-collect-env.
+
+	JFROG_CLI_ENCRYPTION_KEY_FILE
+		[Optional]
+		Path to a file holding the master key, which is used if JFROG_CLI_ENCRYPTION_KEY is not set.
+
+	JFROG_CLI_SERVER_TOKEN_PASSPHRASE
+		[Optional]
+		The passphrase of the server tokens created and imported by the 'jfrog rt config export' and 'jfrog rt config import' commands.
+		Used if the --passphrase option is not set, to avoid exposing the passphrase in the command line.
 		`
@@ -0,0 +1,7 @@
+package decrypt
+
+const Description = "Decrypt the secrets of the JFrog CLI configuration, which is then saved in plain text."
+
+var Usage = []string{"jfrog config decrypt"}
+
+const Arguments string = `	The secrets are decrypted with the master key, which is set by the JFROG_CLI_ENCRYPTION_KEY or JFROG_CLI_ENCRYPTION_KEY_FILE environment variables.`
@@ -0,0 +1,8 @@
+package encrypt
+
+const Description = "Encrypt the passwords, API keys and SSH passphrases of the JFrog CLI configuration."
+
+var Usage = []string{"jfrog config encrypt"}
+
+const Arguments string = `	The secrets are encrypted with the master key, which is set by the JFROG_CLI_ENCRYPTION_KEY or JFROG_CLI_ENCRYPTION_KEY_FILE environment variables.
+	Once the configuration is encrypted, the master key is required by the commands which use the secrets of the configuration.`
@@ -0,0 +1,10 @@
+package run
+
+const Description = "Run the steps of a pipeline file, sharing the build name and number, the Artifactory server and the variables."
+
+var Usage = []string{"jfrog run [command options] <pipeline file>"}
+
+const Arguments string = `	pipeline file
+		Path to a YAML file, which declares the build, server, variables and steps of the pipeline.
+		The supported step commands are: build-publish, docker-push, download, mvn, npm-install, promote and upload.
+		The options of a step are named after the options of its command. Options the command doesn't support fail the pipeline.`
@@ -15,6 +15,7 @@ import (
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/ioutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/tests"
 	cliproxy "github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/tests/proxy/server"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/tests/proxy/server/certificate"
@@ -73,7 +74,7 @@ func authenticate() string {
 	}
 	cred += getArtifactoryTestCredentials()
 	var err error
-	if artAuth, err = artifactoryDetails.CreateArtAuthConfig(); err != nil {
+	if artAuth, err = artifactoryDetails.CreateArtAuthConfig(lock.LockConfig); err != nil {
 		cliutils.ExitOnErr(errors.New("Failed while attempting to authenticate with Artifactory: " + err.Error()))
 	}
 	artifactoryDetails.SshAuthHeaders = artAuth.GetSshAuthHeaders()
@@ -5,8 +5,10 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/bintray"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/config"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/missioncontrol"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/pipeline"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/xray"
 	"github.com/jfrog/jfrog-client-go/utils"
@@ -75,6 +77,7 @@ func main() {
 	app.Version = cliutils.GetVersion()
 	args := os.Args
 	app.Commands = getCommands()
+	app.Flags = getGlobalFlags()
 	cli.CommandHelpTemplate = commandHelpTemplate
 	cli.AppHelpTemplate = appHelpTemplate
 	cli.SubcommandHelpTemplate = subcommandHelpTemplate
@@ -82,6 +85,16 @@ func main() {
 	cliutils.ExitOnErr(err)
 }
 
+func getGlobalFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   "summary-format",
+			EnvVar: "JFROG_CLI_SUMMARY_FORMAT",
+			Usage:  "[Optional] Set to 'json' to print a detailed summary at the end of the command, including the results of the individual files.",
+		},
+	}
+}
+
 func getCommands() []cli.Command {
 	return []cli.Command{
 		{
@@ -104,5 +117,11 @@ func getCommands() []cli.Command {
 			Usage:       "Xray commands",
 			Subcommands: xray.GetCommands(),
 		},
+		{
+			Name:        cliutils.CmdConfig,
+			Usage:       "Config commands",
+			Subcommands: config.GetCommands(),
+		},
+		pipeline.GetCommand(),
 	}
 }
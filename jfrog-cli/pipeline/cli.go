@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/docs/pipeline/run"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/pipeline/commands"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommand() cli.Command {
+	return cli.Command{
+		Name:      cliutils.CmdRun,
+		Usage:     run.Description,
+		HelpName:  common.CreateUsage(cliutils.CmdRun, run.Description, run.Usage),
+		UsageText: run.Arguments,
+		ArgsUsage: common.CreateEnvVars(),
+		Flags:     getRunFlags(),
+		Action: func(c *cli.Context) {
+			runCmd(c)
+		},
+	}
+}
+
+func getRunFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "vars",
+			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\", which override the variables of the pipeline file.",
+		},
+	}
+}
+
+func runCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	pipeline, err := commands.ReadPipeline(c.Args().Get(0))
+	cliutils.ExitOnErr(err)
+	pipelineSummary, err := commands.Run(pipeline, cliutils.SpecVarsStringToMap(c.String("vars")))
+	if pipelineSummary != nil {
+		content, mErr := pipelineSummary.Marshal()
+		if errorutils.CheckError(mErr) != nil {
+			log.Error(mErr)
+		} else {
+			log.Output(utils.IndentJson(content))
+		}
+	}
+	cliutils.ExitOnErr(err)
+}
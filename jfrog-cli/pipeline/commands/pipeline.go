@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The pipeline file, which runs a sequence of JFrog CLI commands sharing the same build and server.
+type Pipeline struct {
+	Version string `yaml:"version"`
+	Build   Build  `yaml:"build"`
+	// The ID of the configured Artifactory server used by the steps, unless a step sets its own server-id option.
+	// If not set, the default server is used.
+	ServerId string            `yaml:"serverId"`
+	Vars     map[string]string `yaml:"vars"`
+	Steps    []Step            `yaml:"steps"`
+}
+
+// The build the steps collect their build-info for.
+type Build struct {
+	Name   string `yaml:"name"`
+	Number string `yaml:"number"`
+}
+
+type Step struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`
+	// The options of the command, named after the options of the matching JFrog CLI command.
+	Options map[string]string `yaml:"options"`
+	// A condition for running the step. The step is skipped if the condition is false.
+	When string `yaml:"when"`
+	// If true, the pipeline continues when the step fails.
+	ContinueOnError bool `yaml:"continueOnError"`
+}
+
+// The variables are referenced in the pipeline values as ${name}.
+var variableRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+const envVariablePrefix = "env."
+
+func ReadPipeline(pipelinePath string) (*Pipeline, error) {
+	content, err := ioutil.ReadFile(pipelinePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pipeline := new(Pipeline)
+	err = yaml.Unmarshal(content, pipeline)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Could not parse the pipeline file " + pipelinePath + ": " + err.Error()))
+	}
+	return pipeline, pipeline.validate()
+}
+
+func (pipeline *Pipeline) validate() error {
+	if len(pipeline.Steps) == 0 {
+		return errorutils.CheckError(errors.New("The pipeline has no steps."))
+	}
+	if (pipeline.Build.Name == "") != (pipeline.Build.Number == "") {
+		return errorutils.CheckError(errors.New("The build name and number of the pipeline cannot be set separately."))
+	}
+	names := make(map[string]bool)
+	for i, step := range pipeline.Steps {
+		if step.Name == "" {
+			return errorutils.CheckError(errors.New("Step " + strconv.Itoa(i+1) + " of the pipeline has no name."))
+		}
+		if names[step.Name] {
+			return errorutils.CheckError(errors.New("The pipeline has more than one step named " + step.Name + "."))
+		}
+		names[step.Name] = true
+		command, exists := stepCommands[step.Command]
+		if !exists {
+			return errorutils.CheckError(errors.New("Step " + step.Name + " has an unsupported command '" + step.Command + "'. The supported commands are: " + strings.Join(getSupportedCommands(), ", ") + "."))
+		}
+		if err := command.validateOptions(&step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The variables the pipeline values may reference: the variables of the pipeline, which can be overridden by the received variables,
+// the build name and number, the status of the steps which ran, and the environment variables, prefixed with 'env.'.
+type variables map[string]string
+
+func newVariables(pipeline *Pipeline, overrides map[string]string) (variables, error) {
+	vars := variables{}
+	for key, value := range pipeline.Vars {
+		vars[key] = value
+	}
+	for key, value := range overrides {
+		vars[key] = value
+	}
+	// The variables may reference the environment variables.
+	for key, value := range vars {
+		replaced, err := variables{}.replace(value)
+		if err != nil {
+			return nil, err
+		}
+		vars[key] = replaced
+	}
+	var err error
+	if vars["build.name"], err = vars.replace(pipeline.Build.Name); err != nil {
+		return nil, err
+	}
+	if vars["build.number"], err = vars.replace(pipeline.Build.Number); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// Replaces the variables referenced by the value. Referencing an undefined variable is an error.
+func (vars variables) replace(value string) (string, error) {
+	var err error
+	replaced := variableRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+		name := strings.TrimSpace(variableRegexp.FindStringSubmatch(reference)[1])
+		if strings.HasPrefix(name, envVariablePrefix) {
+			return os.Getenv(strings.TrimPrefix(name, envVariablePrefix))
+		}
+		replacement, exists := vars[name]
+		if !exists && err == nil {
+			err = errorutils.CheckError(errors.New("The pipeline references an undefined variable: " + name))
+		}
+		return replacement
+	})
+	return replaced, err
+}
+
+func (vars variables) setStepStatus(stepName string, status StepStatus) {
+	vars["steps."+stepName+".status"] = string(status)
+}
+
+// Evaluates the condition of a step, after its variables were replaced.
+// The condition is either a comparison of two values, using == or !=, or a single value, which is false if it is empty, 'false' or '0'.
+func evaluateCondition(condition string) bool {
+	for _, operator := range []string{"==", "!="} {
+		if parts := strings.SplitN(condition, operator, 2); len(parts) == 2 {
+			equal := unquote(parts[0]) == unquote(parts[1])
+			return equal == (operator == "==")
+		}
+	}
+	value := strings.ToLower(unquote(condition))
+	return value != "" && value != "false" && value != "0"
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPipeline = `version: 1
+build:
+    name: my-build
+    number: ${number}
+serverId: my-server
+vars:
+    number: "1"
+    repo: libs-${env.PIPELINE_TEST_SUFFIX}
+steps:
+    - name: upload
+      command: upload
+      options:
+          pattern: out/*.zip
+          target: ${repo}/${build.name}/
+          flat: false
+    - name: publish
+      command: build-publish
+`
+
+func TestReadPipeline(t *testing.T) {
+	pipelinePath := writePipeline(t, testPipeline)
+	defer os.RemoveAll(filepath.Dir(pipelinePath))
+	os.Setenv("PIPELINE_TEST_SUFFIX", "local")
+	defer os.Unsetenv("PIPELINE_TEST_SUFFIX")
+
+	pipeline, err := ReadPipeline(pipelinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pipeline.Steps) != 2 || pipeline.Steps[0].Options["flat"] != "false" || pipeline.ServerId != "my-server" {
+		t.Error("Unexpected pipeline:", pipeline)
+	}
+	vars, err := newVariables(pipeline, map[string]string{"number": "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := vars.replace(pipeline.Steps[0].Options["target"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "libs-local/my-build/" || vars["build.number"] != "2" {
+		t.Error("Unexpected target or build number:", target, vars["build.number"])
+	}
+	if _, err := vars.replace("${undefined}"); err == nil {
+		t.Error("Expected an error for an undefined variable.")
+	}
+}
+
+func TestReadInvalidPipeline(t *testing.T) {
+	for _, content := range []string{
+		"version: 1\n",
+		"steps:\n    - name: a\n      command: rm\n",
+		"steps:\n    - name: a\n      command: upload\n    - name: a\n      command: download\n",
+		"build:\n    name: my-build\nsteps:\n    - name: a\n      command: upload\n",
+		"steps:\n    - name: a\n      command: upload\n      options:\n          pattren: out/*.zip\n",
+	} {
+		pipelinePath := writePipeline(t, content)
+		if _, err := ReadPipeline(pipelinePath); err == nil {
+			t.Error("Expected an error for the pipeline:", content)
+		}
+		os.RemoveAll(filepath.Dir(pipelinePath))
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	for condition, expected := range map[string]bool{
+		"true":                    true,
+		"False":                   false,
+		"0":                       false,
+		"":                        false,
+		"master == master":        true,
+		"'master' == \"release\"": false,
+		"failure != success":      true,
+		"success != success":      false,
+	} {
+		if evaluateCondition(condition) != expected {
+			t.Error("Expected", condition, "to evaluate to", expected)
+		}
+	}
+}
+
+func writePipeline(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "pipeline_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipelinePath := filepath.Join(dir, "pipeline.yaml")
+	if err := ioutil.WriteFile(pipelinePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return pipelinePath
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"strconv"
+	"time"
+)
+
+type StepStatus string
+
+const (
+	StepSuccess StepStatus = "success"
+	StepFailure StepStatus = "failure"
+	StepSkipped StepStatus = "skipped"
+)
+
+// The combined summary of the pipeline steps.
+type Summary struct {
+	Status         summary.StatusType `json:"status"`
+	Totals         *summary.Totals    `json:"totals"`
+	DurationMillis int64              `json:"durationMillis"`
+	Steps          []*StepSummary     `json:"steps"`
+}
+
+type StepSummary struct {
+	Name    string     `json:"name"`
+	Command string     `json:"command"`
+	Status  StepStatus `json:"status"`
+	// The number of files the step succeeded or failed to handle, for the commands which report them.
+	Totals         *summary.Totals `json:"totals,omitempty"`
+	DurationMillis int64           `json:"durationMillis,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+func (pipelineSummary *Summary) Marshal() ([]byte, error) {
+	return json.Marshal(pipelineSummary)
+}
+
+// Runs the pipeline steps in order, with the received variables overriding the variables of the pipeline.
+// Once a step fails, the following steps are skipped, unless the step continues on error. Steps with a condition still run
+// if their condition is true, so that they can handle the failure, using the status variables of the steps.
+// Returns the summary of the steps, and an error if the pipeline failed.
+func Run(pipeline *Pipeline, overrides map[string]string) (*Summary, error) {
+	startTime := time.Now()
+	vars, err := newVariables(pipeline, overrides)
+	if err != nil {
+		return nil, err
+	}
+	pipelineSummary := &Summary{Status: summary.Success, Totals: &summary.Totals{}}
+	failed := false
+	for i := range pipeline.Steps {
+		step := &pipeline.Steps[i]
+		stepSummary := &StepSummary{Name: step.Name, Command: step.Command, Status: StepSkipped}
+		pipelineSummary.Steps = append(pipelineSummary.Steps, stepSummary)
+		if !failed || step.When != "" {
+			runStep(pipeline, step, vars, stepSummary)
+			if stepSummary.Status == StepFailure && !step.ContinueOnError {
+				failed = true
+			}
+		}
+		vars.setStepStatus(step.Name, stepSummary.Status)
+		if stepSummary.Totals != nil {
+			pipelineSummary.Totals.Success += stepSummary.Totals.Success
+			pipelineSummary.Totals.Failure += stepSummary.Totals.Failure
+		}
+	}
+	pipelineSummary.DurationMillis = time.Since(startTime).Nanoseconds() / int64(time.Millisecond)
+	if failed {
+		pipelineSummary.Status = summary.Failure
+		return pipelineSummary, errorutils.CheckError(errors.New("The pipeline failed."))
+	}
+	return pipelineSummary, nil
+}
+
+func runStep(pipeline *Pipeline, step *Step, vars variables, stepSummary *StepSummary) {
+	startTime := time.Now()
+	success, failure, err := evaluateAndRunStep(pipeline, step, vars)
+	if err == errStepSkipped {
+		log.Info("Skipping step", step.Name+", since its condition is false.")
+		return
+	}
+	stepSummary.DurationMillis = time.Since(startTime).Nanoseconds() / int64(time.Millisecond)
+	if success != 0 || failure != 0 {
+		stepSummary.Totals = &summary.Totals{Success: success, Failure: failure}
+	}
+	stepSummary.Status = StepSuccess
+	if err != nil || failure > 0 {
+		stepSummary.Status = StepFailure
+		if err == nil {
+			err = errors.New(strconv.Itoa(failure) + " files failed.")
+		}
+		stepSummary.Error = err.Error()
+		log.Error("Step", step.Name, "failed:", err.Error())
+	}
+}
+
+var errStepSkipped = errors.New("skipped")
+
+func evaluateAndRunStep(pipeline *Pipeline, step *Step, vars variables) (success, failure int, err error) {
+	if step.When != "" {
+		condition, err := vars.replace(step.When)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !evaluateCondition(condition) {
+			return 0, 0, errStepSkipped
+		}
+	}
+	options := stepOptions{}
+	for key, value := range step.Options {
+		if options[key], err = vars.replace(value); err != nil {
+			return 0, 0, err
+		}
+	}
+	context := &stepContext{buildName: vars["build.name"], buildNumber: vars["build.number"], options: options}
+	if context.serverId, err = vars.replace(pipeline.ServerId); err != nil {
+		return 0, 0, err
+	}
+	if serverId, exists := options["server-id"]; exists {
+		context.serverId = serverId
+	}
+	log.Info("Running step", step.Name+"...")
+	return stepCommands[step.Command].run(context)
+}
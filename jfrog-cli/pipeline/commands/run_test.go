@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
+	"reflect"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var ran []string
+	stepCommands["test"] = stepCommand{options: []string{"id", "result"}, run: func(context *stepContext) (int, int, error) {
+		ran = append(ran, context.options["id"])
+		switch context.options["result"] {
+		case "error":
+			return 0, 0, errors.New("step error")
+		case "files":
+			return 2, 1, nil
+		}
+		return 1, 0, nil
+	}}
+	defer delete(stepCommands, "test")
+
+	pipeline := &Pipeline{
+		Build:    Build{Name: "my-build", Number: "1"},
+		ServerId: "my-server",
+		Steps: []Step{
+			{Name: "a", Command: "test", Options: map[string]string{"id": "${build.name}-a"}},
+			{Name: "b", Command: "test", Options: map[string]string{"id": "b", "result": "files"}, ContinueOnError: true},
+			{Name: "c", Command: "test", Options: map[string]string{"id": "c"}, When: "${branch} == release"},
+			{Name: "d", Command: "test", Options: map[string]string{"id": "d", "result": "error"}},
+			{Name: "e", Command: "test", Options: map[string]string{"id": "e"}},
+			{Name: "f", Command: "test", Options: map[string]string{"id": "f"}, When: "${steps.d.status} == failure"},
+		},
+	}
+	pipelineSummary, err := Run(pipeline, map[string]string{"branch": "master"})
+	if err == nil {
+		t.Error("Expected the pipeline to fail.")
+	}
+	if !reflect.DeepEqual(ran, []string{"my-build-a", "b", "d", "f"}) {
+		t.Error("Unexpected steps ran:", ran)
+	}
+	expectedStatuses := []StepStatus{StepSuccess, StepFailure, StepSkipped, StepFailure, StepSkipped, StepSuccess}
+	for i, stepSummary := range pipelineSummary.Steps {
+		if stepSummary.Status != expectedStatuses[i] {
+			t.Error("Expected step", stepSummary.Name, "status", expectedStatuses[i], "got", stepSummary.Status)
+		}
+	}
+	if pipelineSummary.Status != summary.Failure || *pipelineSummary.Totals != (summary.Totals{Success: 4, Failure: 1}) {
+		t.Error("Unexpected pipeline status or totals:", pipelineSummary.Status, *pipelineSummary.Totals)
+	}
+	if pipelineSummary.Steps[3].Error != "step error" {
+		t.Error("Unexpected step error:", pipelineSummary.Steps[3].Error)
+	}
+}
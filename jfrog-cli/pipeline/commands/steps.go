@@ -0,0 +1,376 @@
+package commands
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/commands/docker"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/commands/mvn"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/commands/npm"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli-go/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	clientbuildinfo "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Runs the command of a step, and returns the number of files it succeeded and failed to handle, if the command reports them.
+type stepRunner func(context *stepContext) (success, failure int, err error)
+
+// A command the steps can run, with the options it supports.
+type stepCommand struct {
+	run     stepRunner
+	options []string
+}
+
+// The options of the transfer commands, which are shared by the upload and download.
+var transferOptions = []string{"spec", "pattern", "target", "props", "recursive", "flat", "explode", "module", "dry-run", "threads", "retries", "server-id"}
+
+var stepCommands = map[string]stepCommand{
+	"upload":        {runUpload, append([]string{"regexp", "artifact-type", "deb"}, transferOptions...)},
+	"download":      {runDownload, append([]string{"build"}, transferOptions...)},
+	"mvn":           {runMvn, []string{"goals", "config"}},
+	"npm-install":   {runNpmInstall, []string{"repo", "npm-args", "server-id"}},
+	"docker-push":   {runDockerPush, []string{"image", "repo", "threads", "server-id"}},
+	"build-publish": {runBuildPublish, []string{"build-url", "env-include", "env-exclude", "dry-run", "server-id"}},
+	"promote":       {runPromote, []string{"target-repo", "status", "comment", "source-repo", "copy", "include-dependencies", "dry-run", "server-id"}},
+}
+
+func getSupportedCommands() []string {
+	var commands []string
+	for command := range stepCommands {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// Returns an error if the step sets an option the command doesn't support, so that misspelled options aren't silently ignored.
+func (command stepCommand) validateOptions(step *Step) error {
+	var unsupported []string
+	for key := range step.Options {
+		if !isSupportedOption(command.options, key) {
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	sort.Strings(unsupported)
+	supported := append([]string{}, command.options...)
+	sort.Strings(supported)
+	return errorutils.CheckError(errors.New("Step " + step.Name + " has unsupported options for the " + step.Command + " command: " + strings.Join(unsupported, ", ") + ". The supported options are: " + strings.Join(supported, ", ") + "."))
+}
+
+func isSupportedOption(options []string, key string) bool {
+	for _, option := range options {
+		if option == key {
+			return true
+		}
+	}
+	return false
+}
+
+// The build and server shared by the steps, and the options of the running step, after their variables were replaced.
+type stepContext struct {
+	buildName   string
+	buildNumber string
+	serverId    string
+	options     stepOptions
+}
+
+func (context *stepContext) getArtifactoryDetails() (*config.ArtifactoryDetails, error) {
+	artDetails, err := config.GetArtifactorySpecificConfig(context.serverId)
+	if err != nil {
+		return nil, err
+	}
+	if artDetails.Url == "" {
+		return nil, errorutils.CheckError(errors.New("No Artifactory server is configured for the pipeline. Use 'jfrog rt config' to configure one."))
+	}
+	artDetails.Url = clientutils.AddTrailingSlashIfNeeded(artDetails.Url)
+	return artDetails, nil
+}
+
+func (context *stepContext) requireBuild(command string) error {
+	if context.buildName == "" {
+		return errorutils.CheckError(errors.New("The " + command + " command requires the build name and number of the pipeline."))
+	}
+	return nil
+}
+
+type stepOptions map[string]string
+
+func (options stepOptions) getString(key string) string {
+	return options[key]
+}
+
+func (options stepOptions) getRequiredString(key string) (string, error) {
+	if options[key] == "" {
+		return "", errorutils.CheckError(errors.New("The " + key + " option is mandatory."))
+	}
+	return options[key], nil
+}
+
+func (options stepOptions) getBool(key string, defaultValue bool) (bool, error) {
+	value, exists := options[key]
+	if !exists || value == "" {
+		return defaultValue, nil
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errorutils.CheckError(errors.New("The " + key + " option should have a boolean value."))
+	}
+	return boolValue, nil
+}
+
+func (options stepOptions) getInt(key string, defaultValue int) (int, error) {
+	value, exists := options[key]
+	if !exists || value == "" {
+		return defaultValue, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil || intValue < 0 {
+		return 0, errorutils.CheckError(errors.New("The " + key + " option should have a non-negative numeric value."))
+	}
+	return intValue, nil
+}
+
+// Returns the spec of the spec option, or the spec built by the received function from the pattern and target options.
+func (options stepOptions) getSpec(isTargetMandatory bool, createDefaultSpec func() (*spec.SpecFiles, error)) (*spec.SpecFiles, error) {
+	specPath := options.getString("spec")
+	if specPath == "" {
+		return createDefaultSpec()
+	}
+	specFiles, err := spec.CreateSpecFromFile(specPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return specFiles, spec.ValidateSpec(specFiles.Files, isTargetMandatory)
+}
+
+func runUpload(context *stepContext) (int, int, error) {
+	options := context.options
+	uploadSpec, err := options.getSpec(true, func() (*spec.SpecFiles, error) {
+		pattern, err := options.getRequiredString("pattern")
+		if err != nil {
+			return nil, err
+		}
+		target, err := options.getRequiredString("target")
+		if err != nil {
+			return nil, err
+		}
+		recursive, err := options.getBool("recursive", true)
+		if err != nil {
+			return nil, err
+		}
+		flat, err := options.getBool("flat", true)
+		if err != nil {
+			return nil, err
+		}
+		regexp, err := options.getBool("regexp", false)
+		if err != nil {
+			return nil, err
+		}
+		return spec.NewBuilder().
+			Pattern(pattern).
+			Props(options.getString("props")).
+			Recursive(recursive).
+			Flat(flat).
+			Regexp(regexp).
+			Explode(options.getString("explode")).
+			Target(strings.TrimPrefix(target, "/")).
+			BuildSpec(), nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	configuration := &generic.UploadConfiguration{
+		BuildName:    context.buildName,
+		BuildNumber:  context.buildNumber,
+		Module:       options.getString("module"),
+		ArtifactType: options.getString("artifact-type"),
+		Deb:          options.getString("deb"),
+	}
+	if configuration.DryRun, err = options.getBool("dry-run", false); err != nil {
+		return 0, 0, err
+	}
+	if configuration.Threads, configuration.Retries, err = getThreadsAndRetries(options); err != nil {
+		return 0, 0, err
+	}
+	if configuration.ArtDetails, err = context.getArtifactoryDetails(); err != nil {
+		return 0, 0, err
+	}
+	return generic.Upload(uploadSpec, configuration)
+}
+
+func runDownload(context *stepContext) (int, int, error) {
+	options := context.options
+	downloadSpec, err := options.getSpec(false, func() (*spec.SpecFiles, error) {
+		pattern, err := options.getRequiredString("pattern")
+		if err != nil {
+			return nil, err
+		}
+		recursive, err := options.getBool("recursive", true)
+		if err != nil {
+			return nil, err
+		}
+		flat, err := options.getBool("flat", false)
+		if err != nil {
+			return nil, err
+		}
+		return spec.NewBuilder().
+			Pattern(strings.TrimPrefix(pattern, "/")).
+			Props(options.getString("props")).
+			Build(options.getString("build")).
+			Recursive(recursive).
+			Flat(flat).
+			Explode(options.getString("explode")).
+			Target(options.getString("target")).
+			BuildSpec(), nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	configuration := &generic.DownloadConfiguration{
+		BuildName:    context.buildName,
+		BuildNumber:  context.buildNumber,
+		Module:       options.getString("module"),
+		SplitCount:   cliutils.DownloadSplitCount,
+		MinSplitSize: cliutils.DownloadMinSplitKb,
+		Symlink:      true,
+	}
+	if configuration.DryRun, err = options.getBool("dry-run", false); err != nil {
+		return 0, 0, err
+	}
+	if configuration.Threads, configuration.Retries, err = getThreadsAndRetries(options); err != nil {
+		return 0, 0, err
+	}
+	if configuration.ArtDetails, err = context.getArtifactoryDetails(); err != nil {
+		return 0, 0, err
+	}
+	return generic.Download(downloadSpec, configuration)
+}
+
+func getThreadsAndRetries(options stepOptions) (threads, retries int, err error) {
+	if threads, err = options.getInt("threads", 3); err != nil {
+		return
+	}
+	if threads < 1 {
+		return 0, 0, errorutils.CheckError(errors.New("The threads option should have a numeric positive value."))
+	}
+	retries, err = options.getInt("retries", cliutils.Retries)
+	return
+}
+
+// Maven reads the Artifactory server from its config file, which is created by 'jfrog rt mvn-config'.
+func runMvn(context *stepContext) (int, int, error) {
+	goals, err := context.options.getRequiredString("goals")
+	if err != nil {
+		return 0, 0, err
+	}
+	configPath, err := context.options.getRequiredString("config")
+	if err != nil {
+		return 0, 0, err
+	}
+	configuration := &utils.BuildConfiguration{BuildName: context.buildName, BuildNumber: context.buildNumber}
+	return 0, 0, mvn.Mvn(goals, configPath, configuration)
+}
+
+func runNpmInstall(context *stepContext) (int, int, error) {
+	repo, err := context.options.getRequiredString("repo")
+	if err != nil {
+		return 0, 0, err
+	}
+	configuration := &npmutils.CliConfiguration{BuildName: context.buildName, BuildNumber: context.buildNumber, NpmArgs: context.options.getString("npm-args")}
+	if configuration.ArtDetails, err = context.getArtifactoryDetails(); err != nil {
+		return 0, 0, err
+	}
+	return 0, 0, npm.Install(repo, configuration)
+}
+
+func runDockerPush(context *stepContext) (int, int, error) {
+	imageTag, err := context.options.getRequiredString("image")
+	if err != nil {
+		return 0, 0, err
+	}
+	targetRepo, err := context.options.getRequiredString("repo")
+	if err != nil {
+		return 0, 0, err
+	}
+	threads, _, err := getThreadsAndRetries(context.options)
+	if err != nil {
+		return 0, 0, err
+	}
+	artDetails, err := context.getArtifactoryDetails()
+	if err != nil {
+		return 0, 0, err
+	}
+	return 0, 0, docker.PushDockerImage(imageTag, targetRepo, context.buildName, context.buildNumber, artDetails, threads, nil)
+}
+
+func runBuildPublish(context *stepContext) (int, int, error) {
+	if err := context.requireBuild("build-publish"); err != nil {
+		return 0, 0, err
+	}
+	options := context.options
+	configuration := &clientbuildinfo.Configuration{
+		BuildUrl:   options.getString("build-url"),
+		EnvInclude: options.getString("env-include"),
+		EnvExclude: options.getString("env-exclude"),
+	}
+	if configuration.EnvInclude == "" {
+		configuration.EnvInclude = "*"
+	}
+	if _, exists := options["env-exclude"]; !exists {
+		configuration.EnvExclude = buildinfo.DefaultEnvExclude
+	}
+	var err error
+	if configuration.DryRun, err = options.getBool("dry-run", false); err != nil {
+		return 0, 0, err
+	}
+	artDetails, err := context.getArtifactoryDetails()
+	if err != nil {
+		return 0, 0, err
+	}
+	return 0, 0, buildinfo.Publish(context.buildName, context.buildNumber, configuration, artDetails, nil)
+}
+
+func runPromote(context *stepContext) (int, int, error) {
+	if err := context.requireBuild("promote"); err != nil {
+		return 0, 0, err
+	}
+	options := context.options
+	targetRepo, err := options.getRequiredString("target-repo")
+	if err != nil {
+		return 0, 0, err
+	}
+	promotionParams := &services.PromotionParamsImpl{
+		BuildName:   context.buildName,
+		BuildNumber: context.buildNumber,
+		TargetRepo:  targetRepo,
+		Status:      options.getString("status"),
+		Comment:     options.getString("comment"),
+		SourceRepo:  options.getString("source-repo"),
+	}
+	if promotionParams.Copy, err = options.getBool("copy", false); err != nil {
+		return 0, 0, err
+	}
+	if promotionParams.IncludeDependencies, err = options.getBool("include-dependencies", false); err != nil {
+		return 0, 0, err
+	}
+	configuration := &buildinfo.BuildPromotionConfiguration{PromotionParamsImpl: promotionParams}
+	if configuration.DryRun, err = options.getBool("dry-run", false); err != nil {
+		return 0, 0, err
+	}
+	if configuration.ArtDetails, err = context.getArtifactoryDetails(); err != nil {
+		return 0, 0, err
+	}
+	return 0, 0, buildinfo.Promote(configuration)
+}
@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	CacheMaxSizeEnv = "JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB"
+	// 10 GB
+	DefaultMaxSizeMb = 10240
+	cacheDirName     = "cache"
+	tempFilePrefix   = "tmp-"
+)
+
+var sha1Regexp = regexp.MustCompile("^[0-9a-f]{40}$")
+
+// A local content-addressable files store, keyed by the files SHA1 checksums.
+// The files are stored under <JFrog home dir>/cache/<first 2 checksum chars>/<checksum>.
+// When the total size of the cache exceeds the max size, the least recently used files are evicted.
+// The cache may be shared by several CLI processes, therefore all files are written to temp files
+// first and moved into place, so that a cache file is never seen partially written.
+type Cache struct {
+	dir     string
+	maxSize int64
+}
+
+type Stats struct {
+	Path    string `json:"path,omitempty"`
+	Files   int    `json:"files"`
+	Size    int64  `json:"size"`
+	MaxSize int64  `json:"maxSize"`
+}
+
+type entry struct {
+	path       string
+	size       int64
+	lastAccess time.Time
+}
+
+func New() (*Cache, error) {
+	homeDir, err := config.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := getMaxSize()
+	if err != nil {
+		return nil, err
+	}
+	return NewCache(filepath.Join(homeDir, cacheDirName), maxSize), nil
+}
+
+func NewCache(dir string, maxSize int64) *Cache {
+	return &Cache{dir: dir, maxSize: maxSize}
+}
+
+func (cache *Cache) GetDir() string {
+	return cache.dir
+}
+
+// Places the file with the provided checksum in the target path, if it exists in the cache.
+// The file is copied, rather than linked, so that changes to the target file won't affect the cache.
+// Returns false if the file isn't in the cache.
+func (cache *Cache) Get(sha1 string, size int64, targetPath string) (bool, error) {
+	cachePath, ok := cache.getEntryPath(sha1)
+	if !ok {
+		return false, nil
+	}
+	fileInfo, err := os.Stat(cachePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	if fileInfo.Size() != size {
+		// The cached file is corrupted. It will be replaced by the next put.
+		log.Debug("Ignoring cached file with unexpected size:", cachePath)
+		return false, nil
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(targetPath)); err != nil {
+		return false, err
+	}
+	// The file is copied to a temp file and moved into place, so that the target path never holds a partially copied file.
+	tempFile, err := ioutil.TempFile(filepath.Dir(targetPath), "."+tempFilePrefix)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	tempFile.Close()
+	if err = ioutils.CopyFile(cachePath, tempFile.Name(), 0644); err != nil {
+		os.Remove(tempFile.Name())
+		return false, err
+	}
+	if err = os.Rename(tempFile.Name(), targetPath); err != nil {
+		os.Remove(tempFile.Name())
+		return false, errorutils.CheckError(err)
+	}
+	// The modification time of the cached file is used to track the last access to it.
+	now := time.Now()
+	os.Chtimes(cachePath, now, now)
+	return true, nil
+}
+
+// Adds the file in the source path to the cache.
+// The file is copied, so that later changes to the source file won't affect the cache.
+func (cache *Cache) Put(sha1, srcPath string) error {
+	cachePath, ok := cache.getEntryPath(sha1)
+	if !ok {
+		return nil
+	}
+	exists, err := fileutils.IsFileExists(cachePath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		now := time.Now()
+		return errorutils.CheckError(os.Chtimes(cachePath, now, now))
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(cache.dir, tempFilePrefix)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempFile.Close()
+	if err = ioutils.CopyFile(srcPath, tempFile.Name(), 0644); err != nil {
+		os.Remove(tempFile.Name())
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), cachePath))
+}
+
+// Removes the least recently used files, until the total size of the cache doesn't exceed its max size.
+func (cache *Cache) Evict() error {
+	entries, err := cache.listEntries()
+	if err != nil {
+		return err
+	}
+	var totalSize int64
+	for _, e := range entries {
+		totalSize += e.size
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastAccess.Before(entries[j].lastAccess)
+	})
+	for i := 0; i < len(entries) && totalSize > cache.maxSize; i++ {
+		log.Debug("Evicting cached file:", entries[i].path)
+		if err = os.Remove(entries[i].path); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+		totalSize -= entries[i].size
+	}
+	return nil
+}
+
+// Returns true if no files were added to the cache.
+func (cache *Cache) IsEmpty() (bool, error) {
+	files, err := ioutil.ReadDir(cache.dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	for _, file := range files {
+		// The files are stored in subdirectories, while the cache directory itself holds only temp files.
+		if file.IsDir() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Removes all files from the cache.
+func (cache *Cache) Clean() error {
+	exists, err := fileutils.IsDirExists(cache.dir, false)
+	if err != nil || !exists {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(cache.dir))
+}
+
+func (cache *Cache) Stats() (*Stats, error) {
+	entries, err := cache.listEntries()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Path: cache.dir, Files: len(entries), MaxSize: cache.maxSize}
+	for _, e := range entries {
+		stats.Size += e.size
+	}
+	return stats, nil
+}
+
+func (cache *Cache) getEntryPath(sha1 string) (string, bool) {
+	if !sha1Regexp.MatchString(sha1) {
+		return "", false
+	}
+	return filepath.Join(cache.dir, sha1[:2], sha1), true
+}
+
+func (cache *Cache) listEntries() ([]entry, error) {
+	var entries []entry
+	exists, err := fileutils.IsDirExists(cache.dir, false)
+	if err != nil || !exists {
+		return entries, err
+	}
+	err = filepath.Walk(cache.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// The file may have been removed by another process.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || !sha1Regexp.MatchString(info.Name()) {
+			return nil
+		}
+		entries = append(entries, entry{path: path, size: info.Size(), lastAccess: info.ModTime()})
+		return nil
+	})
+	return entries, errorutils.CheckError(err)
+}
+
+func getMaxSize() (int64, error) {
+	maxSize := os.Getenv(CacheMaxSizeEnv)
+	if maxSize == "" {
+		return DefaultMaxSizeMb * 1024 * 1024, nil
+	}
+	maxSizeMb, err := strconv.ParseInt(maxSize, 10, 64)
+	if err == nil && maxSizeMb < 0 {
+		err = errors.New(CacheMaxSizeEnv + " must be a non-negative number.")
+	}
+	if err = errorutils.CheckError(err); err != nil {
+		return 0, err
+	}
+	return maxSizeMb * 1024 * 1024, nil
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	sha1A = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	sha1B = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+func TestPutAndGet(t *testing.T) {
+	cache, workDir, cleanup := createTestCache(t, 1024)
+	defer cleanup()
+
+	srcPath := createTestFile(t, workDir, "src", "content")
+	if err := cache.Put(sha1A, srcPath); err != nil {
+		t.Fatal(err)
+	}
+	targetPath := filepath.Join(workDir, "target", "a", "file")
+	found, err := cache.Get(sha1A, int64(len("content")), targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("Expected the file to be found in the cache.")
+	}
+	content, err := ioutil.ReadFile(targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "content" {
+		t.Error("Unexpected content:", string(content))
+	}
+
+	// Changing the target file doesn't affect the cached file.
+	if err = ioutil.WriteFile(targetPath, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherTargetPath := filepath.Join(workDir, "target", "b", "file")
+	if found, err = cache.Get(sha1A, int64(len("content")), otherTargetPath); err != nil || !found {
+		t.Fatal("Expected the file to be found in the cache.", err)
+	}
+	if content, err = ioutil.ReadFile(otherTargetPath); err != nil || string(content) != "content" {
+		t.Error("Expected the cached content to be kept, got:", string(content), err)
+	}
+
+	// Missing checksum, size mismatch and invalid checksum are cache misses.
+	for _, test := range []struct {
+		sha1 string
+		size int64
+	}{{sha1B, 7}, {sha1A, 3}, {"../../etc", 7}} {
+		found, err = cache.Get(test.sha1, test.size, targetPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found {
+			t.Error("Expected a cache miss for", test.sha1, test.size)
+		}
+	}
+}
+
+func TestEvictAndStats(t *testing.T) {
+	cache, workDir, cleanup := createTestCache(t, 10)
+	defer cleanup()
+
+	if err := cache.Put(sha1A, createTestFile(t, workDir, "a", "123456")); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the first file is the least recently used.
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(cache.GetDir(), sha1A[:2], sha1A), past, past)
+	if err := cache.Put(sha1B, createTestFile(t, workDir, "b", "123456")); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Files != 2 || stats.Size != 12 {
+		t.Error("Unexpected stats before eviction:", stats)
+	}
+
+	if err = cache.Evict(); err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := cache.Get(sha1A, 6, filepath.Join(workDir, "target")); found {
+		t.Error("Expected the least recently used file to be evicted.")
+	}
+	if found, _ := cache.Get(sha1B, 6, filepath.Join(workDir, "target")); !found {
+		t.Error("Expected the most recently used file to be kept.")
+	}
+
+	if err = cache.Clean(); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Files != 0 || stats.Size != 0 {
+		t.Error("Unexpected stats after clean:", stats)
+	}
+}
+
+func createTestCache(t *testing.T, maxSize int64) (*Cache, string, func()) {
+	tempDir, err := ioutil.TempDir("", "cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCache(filepath.Join(tempDir, "cache"), maxSize), tempDir, func() { os.RemoveAll(tempDir) }
+}
+
+func createTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -11,6 +11,8 @@ const (
 	CmdBintray        = "bt"
 	CmdMissionControl = "mc"
 	CmdXray           = "xr"
+	CmdRun            = "run"
+	CmdConfig         = "config"
 
 	// Download
 	DownloadMinSplitKb    = 5120
@@ -19,4 +21,7 @@ const (
 
 	// Common
 	Retries = 3
+
+	// Summary
+	SummaryFormatJson = "json"
 )
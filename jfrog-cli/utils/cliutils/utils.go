@@ -1,6 +1,7 @@
 package cliutils
 
 import (
+	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/summary"
@@ -96,6 +97,36 @@ func PrintSummaryReport(success, failed int, err error) error {
 	return err
 }
 
+// Returns a detailed summary for the command, if the JSON summary format was requested.
+// Returns nil otherwise, in which case only the totals summary is printed.
+func CreateDetailedSummary(c *cli.Context, command string) *summary.Summary {
+	switch c.GlobalString("summary-format") {
+	case "":
+		return nil
+	case SummaryFormatJson:
+		return summary.NewDetailed(command)
+	default:
+		ExitOnErr(errors.New("The --summary-format option only accepts the value '" + SummaryFormatJson + "'."))
+	}
+	return nil
+}
+
+// Print the detailed summary report, or the totals summary report if the detailed summary is nil.
+// The given error will pass through and be returned as is if no other errors are raised.
+func PrintDetailedSummaryReport(detailedSummary *summary.Summary, success, failed int, err error) error {
+	if detailedSummary == nil {
+		return PrintSummaryReport(success, failed, err)
+	}
+	detailedSummary.Complete(success, failed, err)
+	content, mErr := detailedSummary.Marshal()
+	if errorutils.CheckError(mErr) != nil {
+		log.Error(mErr)
+		return err
+	}
+	log.Output(utils.IndentJson(content))
+	return err
+}
+
 func PrintHelpAndExitWithError(msg string, context *cli.Context) {
 	log.Error(msg + " " + GetDocumentationMessage())
 	cli.ShowCommandHelp(context, context.Command.Name)
@@ -118,10 +149,6 @@ func GetVersion() string {
 	return CliVersion
 }
 
-func GetConfigVersion() string {
-	return "1"
-}
-
 func GetBoolEnvValue(flagName string, defValue bool) (bool, error) {
 	envVarValue := os.Getenv(flagName)
 	if envVarValue == "" {
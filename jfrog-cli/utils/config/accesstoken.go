@@ -0,0 +1,188 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/artifactory/auth"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An access token which expires within this duration is refreshed before it is used.
+const accessTokenRefreshMargin = 5 * time.Minute
+
+// Acquires the lock of the configuration, and returns the function which releases it.
+// The lock package provides it as lock.LockConfig, since it depends on this package.
+type ConfigLocker func() (unlock func() error, err error)
+
+// Guards the access tokens of the details, which are refreshed while they are used, within this process.
+var refreshTokenMutex sync.Mutex
+
+type accessTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// The authentication details of a server which authenticates with an access token.
+// The client creates the headers of every request from these details, so the token is refreshed when it is about to expire
+// while the details are in use, such as during a long upload, and not only when they are created.
+type accessTokenAuth struct {
+	auth.ArtifactoryDetails
+	details *ArtifactoryDetails
+	locker  ConfigLocker
+}
+
+func (tokenAuth *accessTokenAuth) GetSshAuthHeaders() map[string]string {
+	accessToken, err := tokenAuth.details.getAccessToken(tokenAuth.locker)
+	if err != nil {
+		// The headers can't fail, so the current token is sent, and Artifactory rejects it once it expires.
+		log.Warn(err.Error())
+	}
+	headers := utils.CopyMap(tokenAuth.ArtifactoryDetails.GetSshAuthHeaders())
+	headers["Authorization"] = "Bearer " + accessToken
+	return headers
+}
+
+func (tokenAuth *accessTokenAuth) CreateHttpClientDetails() httputils.HttpClientDetails {
+	httpClientDetails := tokenAuth.ArtifactoryDetails.CreateHttpClientDetails()
+	httpClientDetails.Headers = tokenAuth.GetSshAuthHeaders()
+	return httpClientDetails
+}
+
+// Returns the access token, after refreshing it if it is about to expire and a refresh token is available.
+// If the server is configured, the refreshed tokens are written back to the configuration, while it is locked by the locker.
+// A nil locker is allowed only if the caller already holds the lock of the configuration.
+func (artifactoryDetails *ArtifactoryDetails) getAccessToken(locker ConfigLocker) (string, error) {
+	refreshTokenMutex.Lock()
+	defer refreshTokenMutex.Unlock()
+	if artifactoryDetails.RefreshToken == "" || !isAccessTokenExpiring(artifactoryDetails.AccessToken) {
+		return artifactoryDetails.AccessToken, nil
+	}
+	err := artifactoryDetails.refreshAccessToken(locker)
+	return artifactoryDetails.AccessToken, err
+}
+
+func (artifactoryDetails *ArtifactoryDetails) refreshAccessToken(locker ConfigLocker) error {
+	if locker != nil {
+		unlock, err := locker()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	configurations, err := GetAllArtifactoryConfigs()
+	if err != nil {
+		return err
+	}
+	configured := getArtifactoryConfByAccessToken(artifactoryDetails.AccessToken, configurations)
+	if configured == nil {
+		if refreshed := getArtifactoryConfByRefreshToken(artifactoryDetails.RefreshToken, configurations); refreshed != nil {
+			// The tokens were already refreshed by another process.
+			artifactoryDetails.AccessToken = refreshed.AccessToken
+			artifactoryDetails.RefreshToken = refreshed.RefreshToken
+			return nil
+		}
+	}
+
+	log.Debug("Refreshing the access token of", artifactoryDetails.Url)
+	tokens, err := requestAccessTokenRefresh(artifactoryDetails.Url, artifactoryDetails.AccessToken, artifactoryDetails.RefreshToken)
+	if err != nil {
+		return err
+	}
+	artifactoryDetails.AccessToken = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		artifactoryDetails.RefreshToken = tokens.RefreshToken
+	}
+	if configured == nil {
+		// The tokens were provided as command options, so they aren't saved.
+		return nil
+	}
+	configured.AccessToken = artifactoryDetails.AccessToken
+	configured.RefreshToken = artifactoryDetails.RefreshToken
+	return SaveArtifactoryConf(configurations)
+}
+
+// Returns the user and password for the tools which support only basic authentication, such as docker, maven, go and nuget.
+// Artifactory accepts an access token as the password of the user the token was created for.
+func (artifactoryDetails *ArtifactoryDetails) GetBasicAuthCredentials(locker ConfigLocker) (user, password string, err error) {
+	if artifactoryDetails.AccessToken == "" || artifactoryDetails.Password != "" {
+		return artifactoryDetails.User, artifactoryDetails.Password, nil
+	}
+	if artifactoryDetails.User == "" {
+		return "", "", errorutils.CheckError(errors.New("The access token of " + artifactoryDetails.Url + " can be used by this command only with the user the token was created for. Configure the server with the --user option."))
+	}
+	accessToken, err := artifactoryDetails.getAccessToken(locker)
+	return artifactoryDetails.User, accessToken, err
+}
+
+func getArtifactoryConfByAccessToken(accessToken string, configs []*ArtifactoryDetails) *ArtifactoryDetails {
+	for _, conf := range configs {
+		if conf.AccessToken == accessToken {
+			return conf
+		}
+	}
+	return nil
+}
+
+func getArtifactoryConfByRefreshToken(refreshToken string, configs []*ArtifactoryDetails) *ArtifactoryDetails {
+	for _, conf := range configs {
+		if conf.RefreshToken == refreshToken {
+			return conf
+		}
+	}
+	return nil
+}
+
+func requestAccessTokenRefresh(artifactoryUrl, accessToken, refreshToken string) (*accessTokenResponse, error) {
+	params := url.Values{}
+	params.Set("grant_type", "refresh_token")
+	params.Set("access_token", accessToken)
+	params.Set("refresh_token", refreshToken)
+	httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}}
+	client := httpclient.NewDefaultHttpClient()
+	resp, body, err := client.SendPost(utils.AddTrailingSlashIfNeeded(artifactoryUrl)+"api/security/token", []byte(params.Encode()), httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckError(errors.New("Failed refreshing the access token. Artifactory response: " + resp.Status + "\n" + utils.IndentJson(body)))
+	}
+	tokens := new(accessTokenResponse)
+	if err = json.Unmarshal(body, tokens); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if tokens.AccessToken == "" {
+		return nil, errorutils.CheckError(errors.New("Failed refreshing the access token. Artifactory didn't return an access token."))
+	}
+	return tokens, nil
+}
+
+// Access tokens are JWTs, whose expiry is the exp claim of their payload.
+// Tokens which can't be parsed, or have no expiry, are considered as non-expiring.
+func isAccessTokenExpiring(accessToken string) bool {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return false
+	}
+	return time.Unix(claims.Exp, 0).Before(time.Now().Add(accessTokenRefreshMargin))
+}
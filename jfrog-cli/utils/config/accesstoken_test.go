@@ -0,0 +1,136 @@
+package config
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIsAccessTokenExpiring(t *testing.T) {
+	tests := []struct {
+		token    string
+		expiring bool
+	}{
+		{createAccessToken(time.Now().Add(time.Minute)), true},
+		{createAccessToken(time.Now().Add(-time.Hour)), true},
+		{createAccessToken(time.Now().Add(time.Hour)), false},
+		{"header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub": "user"}`)) + ".signature", false},
+		{"not-a-jwt", false},
+	}
+	for _, test := range tests {
+		if isAccessTokenExpiring(test.token) != test.expiring {
+			t.Error("Expected the expiry of", test.token, "to be", test.expiring)
+		}
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHomeDir := os.Getenv(JfrogHomeDirEnv)
+	os.Setenv(JfrogHomeDirEnv, homeDir)
+	defer os.Setenv(JfrogHomeDirEnv, previousHomeDir)
+
+	expiredToken := createAccessToken(time.Now().Add(-time.Hour))
+	refreshedToken := createAccessToken(time.Now().Add(time.Hour))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.URL.Path != "/api/security/token" || r.Form.Get("grant_type") != "refresh_token" ||
+			r.Form.Get("access_token") != expiredToken || r.Form.Get("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"access_token": "` + refreshedToken + `", "refresh_token": "refreshed", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	details := &ArtifactoryDetails{Url: server.URL + "/", AccessToken: expiredToken, RefreshToken: "refresh", ServerId: "server", IsDefault: true}
+	if err = SaveArtifactoryConf([]*ArtifactoryDetails{details}); err != nil {
+		t.Fatal(err)
+	}
+	locks := 0
+	locker := func() (func() error, error) {
+		locks++
+		return func() error { return nil }, nil
+	}
+	usedDetails := *details
+	artAuth, err := usedDetails.CreateArtAuthConfig(locker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorization := artAuth.CreateHttpClientDetails().Headers["Authorization"]; authorization != "Bearer "+refreshedToken {
+		t.Error("Expected the refreshed access token to be sent, got:", authorization)
+	}
+
+	// The refreshed tokens are written back to the configuration.
+	configured, err := GetArtifactorySpecificConfig("server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configured.AccessToken != refreshedToken || configured.RefreshToken != "refreshed" {
+		t.Error("Expected the refreshed tokens to be saved, got:", configured.AccessToken, configured.RefreshToken)
+	}
+	if locks != 1 {
+		t.Error("Expected the configuration to be locked once while the refreshed tokens are saved, got:", locks)
+	}
+
+	// Details which still hold the expired tokens use the tokens refreshed by another process.
+	artAuth, err = details.CreateArtAuthConfig(locker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorization := artAuth.CreateHttpClientDetails().Headers["Authorization"]; authorization != "Bearer "+refreshedToken {
+		t.Error("Expected the saved access token to be sent, got:", authorization)
+	}
+
+	// A token which expires while the details are in use is refreshed before the next request.
+	details = &ArtifactoryDetails{Url: server.URL + "/", AccessToken: createAccessToken(time.Now().Add(time.Hour)), RefreshToken: "refresh"}
+	artAuth, err = details.CreateArtAuthConfig(locker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	details.AccessToken = expiredToken
+	if authorization := artAuth.CreateHttpClientDetails().Headers["Authorization"]; authorization != "Bearer "+refreshedToken {
+		t.Error("Expected the access token to be refreshed while in use, got:", authorization)
+	}
+	if authorization := artAuth.GetSshAuthHeaders()["Authorization"]; authorization != "Bearer "+refreshedToken {
+		t.Error("Expected the refreshed access token in the headers, got:", authorization)
+	}
+}
+
+func TestGetBasicAuthCredentials(t *testing.T) {
+	accessToken := createAccessToken(time.Now().Add(time.Hour))
+	tests := []struct {
+		details          *ArtifactoryDetails
+		expectedUser     string
+		expectedPassword string
+		expectError      bool
+	}{
+		{&ArtifactoryDetails{User: "user", Password: "password"}, "user", "password", false},
+		{&ArtifactoryDetails{User: "user", AccessToken: accessToken}, "user", accessToken, false},
+		{&ArtifactoryDetails{AccessToken: accessToken}, "", "", true},
+		{&ArtifactoryDetails{ApiKey: "apikey"}, "", "", false},
+	}
+	for _, test := range tests {
+		user, password, err := test.details.GetBasicAuthCredentials(nil)
+		if (err != nil) != test.expectError {
+			t.Error("Unexpected error for", test.details, err)
+			continue
+		}
+		if user != test.expectedUser || password != test.expectedPassword {
+			t.Error("Expected", test.expectedUser, test.expectedPassword, "for", test.details, "got:", user, password)
+		}
+	}
+}
+
+func createAccessToken(expiry time.Time) string {
+	payload := `{"sub": "user", "exp": ` + strconv.FormatInt(expiry.Unix(), 10) + `}`
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"github.com/buger/jsonparser"
 	"github.com/jfrog/jfrog-cli-go/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/utils"
@@ -17,6 +16,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -26,12 +26,18 @@ const (
 	JfrogHomeDirEnv   = "JFROG_CLI_HOME_DIR"
 	JfrogConfigFile   = "jfrog-cli.conf"
 	JfrogDependencies = "dependencies"
+	JfrogBuilds       = "builds"
+	// Overrides the configured location of the build-info collected locally.
+	BuildsDirEnv = "JFROG_CLI_BUILDS_DIR"
+	// Overrides the configured number of days after which builds which weren't published are removed.
+	BuildsExpiryDaysEnv     = "JFROG_CLI_BUILDS_EXPIRY_DAYS"
+	DefaultBuildsExpiryDays = 14
 	// Deprecated:
 	JfrogHomeEnv = "JFROG_CLI_HOME"
 )
 
 func IsArtifactoryConfExists() (bool, error) {
-	conf, err := readConf()
+	conf, err := readConfWithoutSecrets()
 	if err != nil {
 		return false, err
 	}
@@ -39,7 +45,7 @@ func IsArtifactoryConfExists() (bool, error) {
 }
 
 func IsMissionControlConfExists() (bool, error) {
-	conf, err := readConf()
+	conf, err := readConfWithoutSecrets()
 	if err != nil {
 		return false, err
 	}
@@ -47,7 +53,7 @@ func IsMissionControlConfExists() (bool, error) {
 }
 
 func IsBintrayConfExists() (bool, error) {
-	conf, err := readConf()
+	conf, err := readConfWithoutSecrets()
 	if err != nil {
 		return false, err
 	}
@@ -139,6 +145,18 @@ func ReadBintrayConf() (*BintrayDetails, error) {
 	return details, nil
 }
 
+func ReadBuildsConf() (*BuildsDetails, error) {
+	conf, err := readConfWithoutSecrets()
+	if err != nil {
+		return nil, err
+	}
+	details := conf.Builds
+	if details == nil {
+		return new(BuildsDetails), nil
+	}
+	return details, nil
+}
+
 func SaveArtifactoryConf(details []*ArtifactoryDetails) error {
 	conf, err := readConf()
 	if err != nil {
@@ -166,12 +184,27 @@ func SaveBintrayConf(details *BintrayDetails) error {
 	return saveConfig(config)
 }
 
+func SaveBuildsConf(details *BuildsDetails) error {
+	config, err := readConfWithoutSecrets()
+	if err != nil {
+		return err
+	}
+	config.Builds = details
+	return saveConfig(config)
+}
+
 func saveConfig(config *ConfigV1) error {
-	config.Version = cliutils.GetConfigVersion()
+	config.Version = GetConfigVersion()
 	b, err := json.Marshal(&config)
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
+	if config.Encrypted && !config.secretsEncrypted {
+		// The secrets are encrypted in a copy of the configuration, since the details of the configuration may still be in use.
+		if b, err = encryptConfigContent(b); err != nil {
+			return err
+		}
+	}
 	var content bytes.Buffer
 	err = json.Indent(&content, b, "", "  ")
 	if err != nil {
@@ -190,7 +223,24 @@ func saveConfig(config *ConfigV1) error {
 	return nil
 }
 
+// Reads the configuration. The secrets of an encrypted configuration are decrypted, which requires the encryption key.
 func readConf() (*ConfigV1, error) {
+	config, err := readConfWithoutSecrets()
+	if err != nil {
+		return nil, err
+	}
+	if config.secretsEncrypted {
+		if err = decryptSecrets(config); err != nil {
+			return nil, err
+		}
+		config.secretsEncrypted = false
+	}
+	return config, nil
+}
+
+// Reads the configuration without decrypting the secrets of an encrypted configuration, so that the encryption key isn't required
+// for reading and saving the details which aren't secret. The secrets are saved as they were read.
+func readConfWithoutSecrets() (*ConfigV1, error) {
 	confFilePath, err := getConfFilePath()
 	if err != nil {
 		return nil, err
@@ -210,34 +260,34 @@ func readConf() (*ConfigV1, error) {
 	if len(content) == 0 {
 		return new(ConfigV1), nil
 	}
-	content, err = convertIfNecessary(content)
-	err = json.Unmarshal(content, &config)
-	return config, err
-}
-
-// The configuration schema can change between versions, therefore we need to convert old versions to the new schema.
-func convertIfNecessary(content []byte) ([]byte, error) {
-	version, err := jsonparser.GetString(content, "Version")
+	converted, err := convertIfNecessary(content)
 	if err != nil {
-		if err.Error() == "Key path not found" {
-			version = "0"
-		} else {
-			return nil, errorutils.CheckError(err)
-		}
+		return nil, err
 	}
-	switch version {
-	case "0":
-		result := new(ConfigV1)
-		configV0 := new(ConfigV0)
-		err = json.Unmarshal(content, &configV0)
-		if errorutils.CheckError(err) != nil {
+	err = json.Unmarshal(converted, &config)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	config.secretsEncrypted = config.Encrypted
+	if !bytes.Equal(converted, content) {
+		if err = backupConfig(confFilePath, content); err != nil {
 			return nil, err
 		}
-		result = configV0.Convert()
-		err = saveConfig(result)
-		content, err = json.Marshal(&result)
+		err = saveConfig(config)
 	}
-	return content, err
+	return config, err
+}
+
+func encryptConfigContent(content []byte) ([]byte, error) {
+	config := new(ConfigV1)
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err := encryptSecrets(config); err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(config)
+	return content, errorutils.CheckError(err)
 }
 
 func GetJfrogHomeDir() (string, error) {
@@ -267,6 +317,46 @@ func GetJfrogDependenciesPath() (string, error) {
 	return filepath.Join(jfrogHome, JfrogDependencies), nil
 }
 
+// Returns the directory of the build-info collected locally, which wasn't published yet.
+// The directory is set by the JFROG_CLI_BUILDS_DIR environment variable or by the configuration, and defaults to the builds directory under the JFrog home directory.
+func GetBuildsDir() (string, error) {
+	if dir := os.Getenv(BuildsDirEnv); dir != "" {
+		return dir, nil
+	}
+	details, err := ReadBuildsConf()
+	if err != nil {
+		return "", err
+	}
+	if details.Dir != "" {
+		return details.Dir, nil
+	}
+	jfrogHome, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(jfrogHome, JfrogBuilds), nil
+}
+
+// Returns the number of days after which builds which weren't published are removed.
+// Zero or a negative number disables the expiry.
+func GetBuildsExpiryDays() (int, error) {
+	if days := os.Getenv(BuildsExpiryDaysEnv); days != "" {
+		expiryDays, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errorutils.CheckError(errors.New(BuildsExpiryDaysEnv + " should be a number of days, got: " + days))
+		}
+		return expiryDays, nil
+	}
+	details, err := ReadBuildsConf()
+	if err != nil {
+		return 0, err
+	}
+	if details.ExpiryDays == nil {
+		return DefaultBuildsExpiryDays, nil
+	}
+	return *details.ExpiryDays, nil
+}
+
 func getConfFilePath() (string, error) {
 	confPath, err := GetJfrogHomeDir()
 	if err != nil {
@@ -280,7 +370,12 @@ type ConfigV1 struct {
 	Artifactory    []*ArtifactoryDetails  `json:"artifactory"`
 	Bintray        *BintrayDetails        `json:"bintray,omitempty"`
 	MissionControl *MissionControlDetails `json:"MissionControl,omitempty"`
+	Builds         *BuildsDetails         `json:"builds,omitempty"`
 	Version        string                 `json:"Version,omitempty"`
+	// If true, the secrets of the configuration are encrypted with the master key.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// True if the secrets were read encrypted and weren't decrypted.
+	secretsEncrypted bool
 }
 
 type ConfigV0 struct {
@@ -310,6 +405,9 @@ type ArtifactoryDetails struct {
 	SshAuthHeaders map[string]string `json:"SshAuthHeaders,omitempty"`
 	ServerId       string            `json:"serverId,omitempty"`
 	IsDefault      bool              `json:"isDefault,omitempty"`
+	// Sent as a bearer token. Refreshed by the refresh token when it is about to expire.
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 	// Deprecated, use password option instead.
 	ApiKey string `json:"apiKey,omitempty"`
 }
@@ -322,6 +420,12 @@ type BintrayDetails struct {
 	DefPackageLicense string `json:"defPackageLicense,omitempty"`
 }
 
+type BuildsDetails struct {
+	Dir string `json:"dir,omitempty"`
+	// A pointer, since zero disables the expiry.
+	ExpiryDays *int `json:"expiryDays,omitempty"`
+}
+
 type MissionControlDetails struct {
 	Url      string `json:"url,omitempty"`
 	User     string `json:"user,omitempty"`
@@ -360,6 +464,10 @@ func (artifactoryDetails *ArtifactoryDetails) GetPassword() string {
 	return artifactoryDetails.Password
 }
 
+func (artifactoryDetails *ArtifactoryDetails) GetAccessToken() string {
+	return artifactoryDetails.AccessToken
+}
+
 func (artifactoryDetails *ArtifactoryDetails) SshAuthHeaderSet() bool {
 	return len(artifactoryDetails.SshAuthHeaders) > 0
 }
@@ -368,13 +476,25 @@ func (artifactoryDetails *ArtifactoryDetails) sshAuthenticationRequired() bool {
 	return !artifactoryDetails.SshAuthHeaderSet() && fileutils.IsSshUrl(artifactoryDetails.Url)
 }
 
-func (artifactoryDetails *ArtifactoryDetails) CreateArtAuthConfig() (auth.ArtifactoryDetails, error) {
-	artAuth := auth.NewArtifactoryDetails()
+// Creates the authentication details of the client.
+// The locker locks the configuration while refreshed access tokens are written back to it.
+func (artifactoryDetails *ArtifactoryDetails) CreateArtAuthConfig(locker ConfigLocker) (auth.ArtifactoryDetails, error) {
+	var artAuth auth.ArtifactoryDetails = auth.NewArtifactoryDetails()
 	artAuth.SetUrl(artifactoryDetails.Url)
 	artAuth.SetSshAuthHeaders(artifactoryDetails.SshAuthHeaders)
 	artAuth.SetApiKey(artifactoryDetails.ApiKey)
 	artAuth.SetUser(artifactoryDetails.User)
 	artAuth.SetPassword(artifactoryDetails.Password)
+	if artifactoryDetails.AccessToken != "" {
+		accessToken, err := artifactoryDetails.getAccessToken(locker)
+		if err != nil {
+			return nil, err
+		}
+		headers := utils.CopyMap(artifactoryDetails.SshAuthHeaders)
+		headers["Authorization"] = "Bearer " + accessToken
+		artAuth.SetSshAuthHeaders(headers)
+		artAuth = &accessTokenAuth{ArtifactoryDetails: artAuth, details: artifactoryDetails, locker: locker}
+	}
 	if artifactoryDetails.sshAuthenticationRequired() {
 		var sshKey, sshPassphrase []byte
 		var err error
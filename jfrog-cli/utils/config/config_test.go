@@ -162,7 +162,7 @@ func TestGetArtifactoriesFromConfig(t *testing.T) {
 }
 
 func assertionHelper(configV1 *ConfigV1, t *testing.T) {
-	if configV1.Version != "1" {
+	if configV1.Version != GetConfigVersion() {
 		t.Error(errors.New("Failed to convert config version."))
 	}
 	rtConverted := configV1.Artifactory
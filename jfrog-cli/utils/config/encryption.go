@@ -0,0 +1,219 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/crypto/pbkdf2"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// The master key, which encrypts the secrets of the configuration when it is encrypted.
+	EncryptionKeyEnv = "JFROG_CLI_ENCRYPTION_KEY"
+	// A file holding the master key, which is used if JFROG_CLI_ENCRYPTION_KEY isn't set.
+	EncryptionKeyFileEnv = "JFROG_CLI_ENCRYPTION_KEY_FILE"
+
+	minEncryptionKeyLength = 32
+
+	passphraseSaltLength    = 16
+	passphraseKeyIterations = 100000
+)
+
+// Encrypts the secrets of the configuration with the master key, which is then required for reading the configuration.
+func EncryptConfig() error {
+	config, err := readConf()
+	if err != nil {
+		return err
+	}
+	if config.Encrypted {
+		return errorutils.CheckError(errors.New("The configuration is already encrypted."))
+	}
+	if _, err = getEncryptionKey(); err != nil {
+		return err
+	}
+	config.Encrypted = true
+	return saveConfig(config)
+}
+
+// Decrypts the secrets of the configuration, which is then saved in plain text.
+func DecryptConfig() error {
+	config, err := readConf()
+	if err != nil {
+		return err
+	}
+	if !config.Encrypted {
+		return errorutils.CheckError(errors.New("The configuration is not encrypted."))
+	}
+	config.Encrypted = false
+	return saveConfig(config)
+}
+
+// Returns the secret fields of the configuration, which are encrypted when the configuration is encrypted.
+func (config *ConfigV1) secrets() []*string {
+	var secrets []*string
+	for _, details := range config.Artifactory {
+		secrets = append(secrets, &details.Password, &details.ApiKey, &details.SshPassphrase, &details.AccessToken, &details.RefreshToken)
+	}
+	if config.Bintray != nil {
+		secrets = append(secrets, &config.Bintray.Key)
+	}
+	if config.MissionControl != nil {
+		secrets = append(secrets, &config.MissionControl.Password)
+	}
+	return secrets
+}
+
+// Returns true if any of the secrets of the configuration is set.
+// The encryption key is required only for encrypting and decrypting secrets, so it isn't required for a configuration without secrets.
+func (config *ConfigV1) hasSecrets() bool {
+	for _, secret := range config.secrets() {
+		if *secret != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func encryptSecrets(config *ConfigV1) error {
+	if !config.hasSecrets() {
+		return nil
+	}
+	gcm, err := createCipher()
+	if err != nil {
+		return err
+	}
+	for _, secret := range config.secrets() {
+		if *secret == "" {
+			continue
+		}
+		encrypted, err := seal(gcm, []byte(*secret))
+		if err != nil {
+			return err
+		}
+		*secret = base64.StdEncoding.EncodeToString(encrypted)
+	}
+	return nil
+}
+
+// Encrypts the content with a random nonce, which is prepended to the encrypted content.
+func seal(gcm cipher.AEAD, content []byte) ([]byte, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return gcm.Seal(nonce, nonce, content, nil), nil
+}
+
+func decryptSecrets(config *ConfigV1) error {
+	if !config.hasSecrets() {
+		return nil
+	}
+	gcm, err := createCipher()
+	if err != nil {
+		return err
+	}
+	for _, secret := range config.secrets() {
+		if *secret == "" {
+			continue
+		}
+		encrypted, err := base64.StdEncoding.DecodeString(*secret)
+		if err != nil || len(encrypted) < gcm.NonceSize() {
+			return errorutils.CheckError(errors.New("The configuration includes a secret which is not encrypted properly."))
+		}
+		decrypted, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+		if err != nil {
+			return errorutils.CheckError(errors.New("Failed decrypting the configuration. Make sure the encryption key is the key the configuration was encrypted with."))
+		}
+		*secret = string(decrypted)
+	}
+	return nil
+}
+
+func createCipher() (cipher.AEAD, error) {
+	key, err := getEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	return createCipherWithKey(key)
+}
+
+func createCipherWithKey(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errorutils.CheckError(err)
+}
+
+// Returns the AES-256 key derived from the master key.
+func getEncryptionKey() ([]byte, error) {
+	masterKey := os.Getenv(EncryptionKeyEnv)
+	if masterKey == "" {
+		if keyFile := os.Getenv(EncryptionKeyFileEnv); keyFile != "" {
+			content, err := ioutil.ReadFile(keyFile)
+			if err != nil {
+				return nil, errorutils.CheckError(errors.New("Failed reading the encryption key file: " + err.Error()))
+			}
+			masterKey = strings.TrimSpace(string(content))
+		}
+	}
+	if masterKey == "" {
+		return nil, errorutils.CheckError(errors.New("The encryption key is missing. Set it using the " + EncryptionKeyEnv + " or " + EncryptionKeyFileEnv + " environment variables."))
+	}
+	if len(masterKey) < minEncryptionKeyLength {
+		return nil, errorutils.CheckError(errors.New("The encryption key should be at least " + strconv.Itoa(minEncryptionKeyLength) + " characters long."))
+	}
+	key := sha256.Sum256([]byte(masterKey))
+	return key[:], nil
+}
+
+// Encrypts the content with a key derived from the passphrase. The random salt of the key is prepended to the encrypted content.
+func EncryptWithPassphrase(content []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, passphraseSaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := createCipherWithKey(getPassphraseKey(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := seal(gcm, content)
+	if err != nil {
+		return nil, err
+	}
+	return append(salt, encrypted...), nil
+}
+
+func DecryptWithPassphrase(encrypted []byte, passphrase string) ([]byte, error) {
+	if len(encrypted) < passphraseSaltLength {
+		return nil, errorutils.CheckError(errors.New("The encrypted content is too short."))
+	}
+	gcm, err := createCipherWithKey(getPassphraseKey(passphrase, encrypted[:passphraseSaltLength]))
+	if err != nil {
+		return nil, err
+	}
+	encrypted = encrypted[passphraseSaltLength:]
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, errorutils.CheckError(errors.New("The encrypted content is too short."))
+	}
+	decrypted, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed decrypting the content. Make sure the passphrase is the passphrase it was encrypted with."))
+	}
+	return decrypted, nil
+}
+
+// Returns the AES-256 key derived from the passphrase.
+func getPassphraseKey(passphrase string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, passphraseKeyIterations, 32, sha256.New)
+}
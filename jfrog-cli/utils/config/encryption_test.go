@@ -0,0 +1,117 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptConfig(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHomeDir := os.Getenv(JfrogHomeDirEnv)
+	os.Setenv(JfrogHomeDirEnv, homeDir)
+	defer os.Setenv(JfrogHomeDirEnv, previousHomeDir)
+	defer os.Unsetenv(EncryptionKeyEnv)
+
+	artifactoryDetails := []*ArtifactoryDetails{{Url: "http://localhost:8081/artifactory/", User: "user", Password: "password", ServerId: "server", IsDefault: true}}
+	if err = SaveArtifactoryConf(artifactoryDetails); err != nil {
+		t.Fatal(err)
+	}
+	if err = SaveBintrayConf(&BintrayDetails{User: "user", Key: "bintray-key"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The master key is required for encrypting the configuration.
+	os.Unsetenv(EncryptionKeyEnv)
+	if err = EncryptConfig(); err == nil {
+		t.Fatal("Expected an error for a missing encryption key.")
+	}
+	os.Setenv(EncryptionKeyEnv, "short")
+	if err = EncryptConfig(); err == nil {
+		t.Fatal("Expected an error for a short encryption key.")
+	}
+	os.Setenv(EncryptionKeyEnv, strings.Repeat("k", 32))
+	if err = EncryptConfig(); err != nil {
+		t.Fatal(err)
+	}
+	assertConfigFileContains(t, homeDir, false)
+
+	// The secrets are decrypted when the configuration is read, and stay encrypted when it is saved.
+	details, err := GetArtifactorySpecificConfig("server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Password != "password" {
+		t.Error("Expected the decrypted password, got:", details.Password)
+	}
+	details.User = "other"
+	if err = SaveArtifactoryConf([]*ArtifactoryDetails{details}); err != nil {
+		t.Fatal(err)
+	}
+	if details.Password != "password" {
+		t.Error("Expected the saved details to keep the decrypted password, got:", details.Password)
+	}
+	assertConfigFileContains(t, homeDir, false)
+
+	// The details which aren't secret are read and saved without the key, and the secrets stay encrypted.
+	os.Unsetenv(EncryptionKeyEnv)
+	if err = SaveBuildsConf(&BuildsDetails{Dir: "builds"}); err != nil {
+		t.Fatal(err)
+	}
+	buildsDetails, err := ReadBuildsConf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buildsDetails.Dir != "builds" {
+		t.Error("Expected the saved builds details, got:", buildsDetails)
+	}
+	if _, err = GetArtifactorySpecificConfig("server"); err == nil {
+		t.Error("Expected an error for reading the secrets without the key.")
+	}
+	assertConfigFileContains(t, homeDir, false)
+	os.Setenv(EncryptionKeyEnv, strings.Repeat("k", 32))
+	if details, err = GetArtifactorySpecificConfig("server"); err != nil || details.Password != "password" {
+		t.Error("Expected the decrypted password, got:", details, err)
+	}
+
+	// A wrong key fails reading the configuration.
+	os.Setenv(EncryptionKeyEnv, strings.Repeat("x", 32))
+	if _, err = ReadBintrayConf(); err == nil {
+		t.Error("Expected an error for a wrong encryption key.")
+	}
+
+	os.Setenv(EncryptionKeyEnv, strings.Repeat("k", 32))
+	if err = DecryptConfig(); err != nil {
+		t.Fatal(err)
+	}
+	assertConfigFileContains(t, homeDir, true)
+	os.Unsetenv(EncryptionKeyEnv)
+	bintrayDetails, err := ReadBintrayConf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bintrayDetails.Key != "bintray-key" {
+		t.Error("Expected the decrypted Bintray key, got:", bintrayDetails.Key)
+	}
+}
+
+func assertConfigFileContains(t *testing.T, homeDir string, plainText bool) {
+	content, err := ioutil.ReadFile(filepath.Join(homeDir, JfrogConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"\"password\": \"password\"", "bintray-key"} {
+		if strings.Contains(string(content), secret) != plainText {
+			t.Error("Unexpected config file content:", string(content))
+		}
+	}
+	if strings.Contains(string(content), "\"encrypted\": true") == plainText {
+		t.Error("Unexpected encryption mode of the config file:", string(content))
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"strconv"
+)
+
+// Converts the content of the configuration from the preceding version to the version of the migration.
+type configMigration struct {
+	version int
+	migrate func(content []byte) ([]byte, error)
+}
+
+// The migrations of the configuration schema, ordered by their versions.
+// The version of the last migration is the current version, returned by GetConfigVersion.
+// A schema change is added as a migration from the current version, rather than by changing the existing migrations.
+var configMigrations = []configMigration{
+	{version: 1, migrate: convertConfigV0ToV1},
+	{version: 2, migrate: convertConfigV1ToV2},
+}
+
+// Returns the current version of the configuration schema.
+func GetConfigVersion() string {
+	return strconv.Itoa(configMigrations[len(configMigrations)-1].version)
+}
+
+// The configuration schema can change between versions, therefore we need to convert old versions to the new schema.
+func convertIfNecessary(content []byte) ([]byte, error) {
+	return migrateConfig(content, configMigrations)
+}
+
+// Applies the migrations from the version of the content to the last migration, one version at a time.
+func migrateConfig(content []byte, migrations []configMigration) ([]byte, error) {
+	version, err := getConfigVersion(content)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion := 0
+	if len(migrations) > 0 {
+		currentVersion = migrations[len(migrations)-1].version
+	}
+	if version > currentVersion {
+		return nil, errorutils.CheckError(errors.New(fmt.Sprintf("The configuration of version %d was created by a newer version of JFrog CLI, which supports configuration version %d. Upgrade JFrog CLI to use it.", version, currentVersion)))
+	}
+	for _, migration := range migrations {
+		if migration.version <= version {
+			continue
+		}
+		log.Debug(fmt.Sprintf("Converting the configuration from version %d to version %d.", version, migration.version))
+		if content, err = migration.migrate(content); err != nil {
+			return nil, err
+		}
+		version = migration.version
+		if content, err = jsonparser.Set(content, []byte(strconv.Quote(strconv.Itoa(version))), "Version"); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return content, nil
+}
+
+// Returns the version of the configuration content. The first configuration had no version, and is considered as version 0.
+func getConfigVersion(content []byte) (int, error) {
+	version, err := jsonparser.GetString(content, "Version")
+	if err == jsonparser.KeyPathNotFoundError {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, errorutils.CheckError(errors.New("The configuration version should be a number, got: " + version))
+	}
+	return versionNumber, nil
+}
+
+// Keeps the configuration before its conversion, so that it can be restored, for example by an older version of JFrog CLI.
+func backupConfig(confFilePath string, content []byte) error {
+	version, err := getConfigVersion(content)
+	if err != nil {
+		return err
+	}
+	backupPath := confFilePath + ".v" + strconv.Itoa(version) + ".bak"
+	log.Info("Converting the configuration to version " + GetConfigVersion() + ". The previous configuration is kept in " + backupPath)
+	return errorutils.CheckError(ioutil.WriteFile(backupPath, content, 0600))
+}
+
+func convertConfigV0ToV1(content []byte) ([]byte, error) {
+	configV0 := new(ConfigV0)
+	if err := json.Unmarshal(content, &configV0); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	content, err := json.Marshal(configV0.Convert())
+	return content, errorutils.CheckError(err)
+}
+
+// Version 2 adds the encryption mode, the access and refresh tokens of the servers and the builds details.
+// They are optional, so the content is kept as is. The new version prevents JFrog CLIs which don't support them from using the configuration.
+func convertConfigV1ToV2(content []byte) ([]byte, error) {
+	return content, nil
+}
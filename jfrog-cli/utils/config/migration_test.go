@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"github.com/buger/jsonparser"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	// Each migration records its version in the migrations field.
+	recordMigration := func(version int) func([]byte) ([]byte, error) {
+		return func(content []byte) ([]byte, error) {
+			migrations, _ := jsonparser.GetString(content, "migrations")
+			return jsonparser.Set(content, []byte(strconv.Quote(migrations+strconv.Itoa(version))), "migrations")
+		}
+	}
+	migrations := []configMigration{{version: 1, migrate: recordMigration(1)}, {version: 2, migrate: recordMigration(2)}}
+
+	tests := []struct {
+		content            string
+		expectedMigrations string
+	}{
+		{`{}`, "12"},
+		{`{"Version": "1"}`, "2"},
+		{`{"Version": "2"}`, ""},
+	}
+	for _, test := range tests {
+		content, err := migrateConfig([]byte(test.content), migrations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		appliedMigrations, _ := jsonparser.GetString(content, "migrations")
+		if appliedMigrations != test.expectedMigrations {
+			t.Error("Expected the migrations", test.expectedMigrations, "to be applied to", test.content, "got:", appliedMigrations)
+		}
+		if version, err := getConfigVersion(content); err != nil || version != 2 {
+			t.Error("Expected the content to be converted to version 2, got:", string(content))
+		}
+	}
+
+	// A configuration of a newer version isn't read.
+	if _, err := migrateConfig([]byte(`{"Version": "3"}`), migrations); err == nil {
+		t.Error("Expected an error for a configuration of a newer version.")
+	}
+	if _, err := migrateConfig([]byte(`{"Version": "a"}`), migrations); err == nil {
+		t.Error("Expected an error for an invalid version.")
+	}
+}
+
+func TestConfigMigrationsVersions(t *testing.T) {
+	for i := 1; i < len(configMigrations); i++ {
+		if configMigrations[i].version <= configMigrations[i-1].version {
+			t.Error("The configuration migrations should be ordered by their versions.")
+		}
+	}
+}
+
+func TestConvertConfigV1ToV2(t *testing.T) {
+	configV1 := `{"artifactory": [{"url": "http://localhost:8080/artifactory/", "serverId": "server", "accessToken": "token", "refreshToken": "refresh", "isDefault": true}], "Version": "1"}`
+	content, err := convertIfNecessary([]byte(configV1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := new(ConfigV1)
+	if err = json.Unmarshal(content, config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Version != GetConfigVersion() || len(config.Artifactory) != 1 ||
+		config.Artifactory[0].AccessToken != "token" || config.Artifactory[0].RefreshToken != "refresh" {
+		t.Error("Unexpected converted configuration:", string(content))
+	}
+}
+
+func TestReadConfBackup(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHomeDir := os.Getenv(JfrogHomeDirEnv)
+	os.Setenv(JfrogHomeDirEnv, homeDir)
+	defer os.Setenv(JfrogHomeDirEnv, previousHomeDir)
+
+	confFilePath := filepath.Join(homeDir, JfrogConfigFile)
+	configV0 := `{"artifactory": {"url": "http://localhost:8080/artifactory/", "user": "user", "password": "password"}}`
+	if err = ioutil.WriteFile(confFilePath, []byte(configV0), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := readConf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Artifactory) != 1 || config.Artifactory[0].ServerId != DefaultServerId {
+		t.Error("Unexpected converted configuration:", config.Artifactory)
+	}
+
+	// The original configuration is backed up, and the converted configuration is saved.
+	backup, err := ioutil.ReadFile(confFilePath + ".v0.bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != configV0 {
+		t.Error("Unexpected backup:", string(backup))
+	}
+	content, err := ioutil.ReadFile(confFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := new(ConfigV1)
+	if err = json.Unmarshal(content, saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Version != GetConfigVersion() {
+		t.Error("Expected the converted configuration to be saved, got:", string(content))
+	}
+
+	// A configuration created by a newer version of JFrog CLI is neither read nor overwritten.
+	configV99 := `{"Version": "99"}`
+	if err = ioutil.WriteFile(confFilePath, []byte(configV99), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = SaveBintrayConf(&BintrayDetails{User: "user"}); err == nil {
+		t.Error("Expected an error for a configuration of a newer version.")
+	}
+	if content, err = ioutil.ReadFile(confFilePath); err != nil || string(content) != configV99 {
+		t.Error("Expected the configuration of a newer version to be kept, got:", string(content))
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ProjectConfigDir  = ".jfrog"
+	ProjectConfigFile = "project.yaml"
+	// The package managers which read their repositories from the project configuration.
+	Npm    = "npm"
+	Nuget  = "nuget"
+	Go     = "go"
+	Maven  = "maven"
+	Gradle = "gradle"
+)
+
+// The configuration of a project, which is committed with its sources, so that its developers share the same settings.
+// It overlays the JFrog CLI configuration, and therefore doesn't include any secrets.
+type ProjectConfig struct {
+	// The configured server which is used instead of the default server.
+	ServerId     string                       `yaml:"serverId,omitempty"`
+	Repositories map[string]ProjectRepository `yaml:"repositories,omitempty"`
+	Build        ProjectBuild                 `yaml:"build,omitempty"`
+	// The path of the project configuration file. Empty if the project has no configuration.
+	Path string `yaml:"-"`
+}
+
+type ProjectRepository struct {
+	// The repository from which the dependencies are resolved.
+	Resolve string `yaml:"resolve,omitempty"`
+	// The repository to which the packages are published.
+	Deploy string `yaml:"deploy,omitempty"`
+}
+
+type ProjectBuild struct {
+	// The build name, which is used when a build number is set without a build name.
+	// Environment variables in the name are expanded, so that the name can follow a convention, such as ${BRANCH_NAME}.
+	Name string `yaml:"name,omitempty"`
+}
+
+// Returns the configuration of the project of the working directory, which is found in the .jfrog directory of the working directory or of one of its parents.
+// Returns an empty configuration if none of them includes a project configuration.
+func GetProjectConfig() (*ProjectConfig, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	projectConfigPath, err := findProjectConfig(wd)
+	if err != nil || projectConfigPath == "" {
+		return new(ProjectConfig), err
+	}
+	return readProjectConfig(projectConfigPath)
+}
+
+func findProjectConfig(dir string) (string, error) {
+	for {
+		projectConfigPath := filepath.Join(dir, ProjectConfigDir, ProjectConfigFile)
+		exists, err := fileutils.IsFileExists(projectConfigPath, false)
+		if err != nil || exists {
+			return projectConfigPath, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func readProjectConfig(projectConfigPath string) (*ProjectConfig, error) {
+	content, err := fileutils.ReadFile(projectConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	projectConfig := new(ProjectConfig)
+	// Unknown fields, such as credentials, are rejected.
+	if err = yaml.UnmarshalStrict(content, projectConfig); err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed reading the project configuration " + projectConfigPath + ": " + err.Error()))
+	}
+	for packageManager := range projectConfig.Repositories {
+		if packageManager != Npm && packageManager != Nuget && packageManager != Go && packageManager != Maven && packageManager != Gradle {
+			return nil, errorutils.CheckError(errors.New("The project configuration " + projectConfigPath + " includes repositories of " + packageManager + ", while it supports only the repositories of " + Npm + ", " + Nuget + ", " + Go + ", " + Maven + " and " + Gradle + "."))
+		}
+	}
+	projectConfig.Build.Name = os.ExpandEnv(projectConfig.Build.Name)
+	projectConfig.Path = projectConfigPath
+	log.Debug("Using the project configuration:", projectConfigPath)
+	return projectConfig, nil
+}
+
+// Returns the repository from which the dependencies of the package manager are resolved, or to which its packages are deployed.
+func (projectConfig *ProjectConfig) GetRepository(packageManager string, deploy bool) string {
+	repository := projectConfig.Repositories[packageManager]
+	if deploy {
+		return repository.Deploy
+	}
+	return repository.Resolve
+}
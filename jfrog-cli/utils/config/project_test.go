@@ -0,0 +1,81 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetProjectConfig(t *testing.T) {
+	projectDir, err := ioutil.TempDir("", "project_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+	previousHomeDir := os.Getenv(JfrogHomeDirEnv)
+	os.Setenv(JfrogHomeDirEnv, filepath.Join(projectDir, "home"))
+	defer os.Setenv(JfrogHomeDirEnv, previousHomeDir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// The project configuration is found in the parent directories of the working directory.
+	subDir := filepath.Join(projectDir, "a", "b")
+	if err = os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(subDir); err != nil {
+		t.Fatal(err)
+	}
+	projectConfig, err := GetProjectConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projectConfig.Path != "" {
+		t.Error("Expected no project configuration, got:", projectConfig.Path)
+	}
+
+	os.Setenv("PROJECT_TEST_BRANCH", "master")
+	defer os.Unsetenv("PROJECT_TEST_BRANCH")
+	writeProjectConfig(t, projectDir, "serverId: project-server\nrepositories:\n  npm:\n    resolve: npm-virtual\n    deploy: npm-local\n  maven:\n    resolve: maven-virtual\nbuild:\n  name: app-${PROJECT_TEST_BRANCH}\n")
+	projectConfig, err = GetProjectConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projectConfig.ServerId != "project-server" || projectConfig.Build.Name != "app-master" ||
+		projectConfig.GetRepository(Npm, false) != "npm-virtual" || projectConfig.GetRepository(Npm, true) != "npm-local" || projectConfig.GetRepository(Go, false) != "" ||
+		projectConfig.GetRepository(Maven, false) != "maven-virtual" {
+		t.Error("Unexpected project configuration:", projectConfig)
+	}
+
+	// The server of the project is applied by the commands, so the configuration returns the default server regardless of the working directory.
+	servers := []*ArtifactoryDetails{{Url: "http://default/", ServerId: "default", IsDefault: true}, {Url: "http://project/", ServerId: "project-server"}}
+	if err = SaveArtifactoryConf(servers); err != nil {
+		t.Fatal(err)
+	}
+	if details, err := GetArtifactorySpecificConfig(""); err != nil || details.ServerId != "default" {
+		t.Error("Expected the default server, got:", details, err)
+	}
+
+	// Secrets and unsupported package managers are rejected.
+	writeProjectConfig(t, projectDir, "serverId: project-server\npassword: secret\n")
+	if _, err = GetProjectConfig(); err == nil {
+		t.Error("Expected an error for a project configuration with a password.")
+	}
+	writeProjectConfig(t, projectDir, "repositories:\n  pip:\n    resolve: pypi\n")
+	if _, err = GetProjectConfig(); err == nil {
+		t.Error("Expected an error for an unsupported package manager.")
+	}
+}
+
+func writeProjectConfig(t *testing.T, projectDir, content string) {
+	if err := os.MkdirAll(filepath.Join(projectDir, ProjectConfigDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(projectDir, ProjectConfigDir, ProjectConfigFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"strings"
+)
+
+const (
+	// The passphrase of the server tokens, which is used if the --passphrase option isn't set.
+	ServerTokenPassphraseEnv = "JFROG_CLI_SERVER_TOKEN_PASSPHRASE"
+
+	serverTokenVersion = 1
+)
+
+// The content of a server token, which holds the details of a single configured server.
+// If the token is encrypted with a passphrase, the details are held by the encrypted field.
+type serverToken struct {
+	Version   int                 `json:"version"`
+	Details   *ArtifactoryDetails `json:"details,omitempty"`
+	Encrypted []byte              `json:"encrypted,omitempty"`
+}
+
+// Creates a token of the server details, which can be imported by another JFrog CLI.
+// If the passphrase isn't empty, the details are encrypted with it.
+// Servers which authenticate with an SSH key file aren't exported, since the path of the file is valid only on this machine.
+func CreateServerToken(details *ArtifactoryDetails, passphrase string) (string, error) {
+	if details.SshKeyPath != "" {
+		return "", errorutils.CheckError(errors.New("Server ID '" + details.ServerId + "' authenticates with the SSH key file " + details.SshKeyPath + ", which is local to this machine. Configure the server with the SSH key on the importing machine instead."))
+	}
+	exported := *details
+	// The default server is set by the importing configuration.
+	exported.IsDefault = false
+	token := serverToken{Version: serverTokenVersion, Details: &exported}
+	if passphrase != "" {
+		content, err := json.Marshal(&exported)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		if token.Encrypted, err = EncryptWithPassphrase(content, passphrase); err != nil {
+			return "", err
+		}
+		token.Details = nil
+	}
+	content, err := json.Marshal(&token)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(content), nil
+}
+
+// Returns true if the token created by CreateServerToken is encrypted with a passphrase.
+func IsServerTokenEncrypted(token string) (bool, error) {
+	parsed, err := parseServerToken(token)
+	if err != nil {
+		return false, err
+	}
+	return parsed.Encrypted != nil, nil
+}
+
+// Returns the server details of a token created by CreateServerToken.
+func ReadServerToken(token, passphrase string) (*ArtifactoryDetails, error) {
+	parsed, err := parseServerToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Encrypted != nil {
+		if passphrase == "" {
+			return nil, errorutils.CheckError(errors.New("The server token is encrypted. Set the passphrase it was encrypted with using the --passphrase option or the " + ServerTokenPassphraseEnv + " environment variable."))
+		}
+		content, err := DecryptWithPassphrase(parsed.Encrypted, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Details = new(ArtifactoryDetails)
+		if err = json.Unmarshal(content, parsed.Details); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	if parsed.Details == nil || parsed.Details.ServerId == "" || parsed.Details.Url == "" {
+		return nil, errorutils.CheckError(errors.New("The server token doesn't include the server ID and URL."))
+	}
+	return parsed.Details, nil
+}
+
+func parseServerToken(token string) (*serverToken, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("The server token is invalid: " + err.Error()))
+	}
+	parsed := new(serverToken)
+	if err = json.Unmarshal(content, parsed); err != nil {
+		return nil, errorutils.CheckError(errors.New("The server token is invalid: " + err.Error()))
+	}
+	if parsed.Version != serverTokenVersion {
+		return nil, errorutils.CheckError(errors.New("The server token was created by an incompatible version of JFrog CLI."))
+	}
+	return parsed, nil
+}
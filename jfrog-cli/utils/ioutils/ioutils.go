@@ -35,6 +35,15 @@ func ReadCredentialsFromConsole(details, savedDetails cliutils.Credentials, allo
 	return nil
 }
 
+// Reads a secret from the console, without echoing it.
+func ScanPasswordFromConsole(caption string) (string, error) {
+	print(caption + ": ")
+	bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
+	// The newline typed after the secret isn't echoed either.
+	print("\n")
+	return string(bytePassword), errorutils.CheckError(err)
+}
+
 func ScanFromConsole(caption string, scanInto *string, defaultValue string) {
 	if defaultValue != "" {
 		print(caption + " [" + defaultValue + "]: ")
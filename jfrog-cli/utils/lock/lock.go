@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,8 +22,15 @@ type Lock struct {
 	// The full path to the lock file.
 	fileName string
 	pid      int
+	// The directory of the lock files. If empty, the lock directory within JFrog CLI Home Dir is used.
+	dir string
 }
 
+// The last time used by a lock of this process.
+// Locks created by the same process at the same time are given different times, so that they can be ordered.
+var lastLockTime int64
+var lastLockTimeMutex sync.Mutex
+
 type Locks []Lock
 
 func (locks Locks) Len() int {
@@ -39,7 +47,7 @@ func (locks Locks) Less(i, j int) bool {
 
 // Creating a new lock object.
 func (lock *Lock) CreateNewLockFile() error {
-	lock.currentTime = time.Now().UnixNano()
+	lock.currentTime = newLockTime()
 	folderName, err := lock.createLockDirWithPermissions()
 	if err != nil {
 		return err
@@ -68,14 +76,31 @@ func (lock *Lock) CreateFile(folderName string, pid int) error {
 	return nil
 }
 
+func newLockTime() int64 {
+	lastLockTimeMutex.Lock()
+	defer lastLockTimeMutex.Unlock()
+	currentTime := time.Now().UnixNano()
+	if currentTime <= lastLockTime {
+		currentTime = lastLockTime + 1
+	}
+	lastLockTime = currentTime
+	return currentTime
+}
+
 func (lock *Lock) createLockDirWithPermissions() (string, error) {
-	homeDir, err := config.GetJfrogHomeDir()
+	folderName := lock.dir
+	if folderName == "" {
+		homeDir, err := config.GetJfrogHomeDir()
+		if err != nil {
+			return "", err
+		}
+		// The lock created in the lock folder within JFrog CLI Home Dir
+		folderName = filepath.Join(homeDir, "lock")
+	}
+	exists, err := fileutils.IsDirExists(folderName, false)
 	if err != nil {
 		return "", err
 	}
-	// The lock created in the lock folder within JFrog CLI Home Dir
-	folderName := filepath.Join(homeDir, "lock")
-	exists, err := fileutils.IsDirExists(folderName, false)
 	if !exists {
 		err = fileutils.CreateDirIfNotExist(folderName)
 		if err != nil {
@@ -234,7 +259,13 @@ func (lock *Lock) Unlock() error {
 }
 
 func CreateLock() (Lock, error) {
-	lockFile := new(Lock)
+	return CreateLockInDir("")
+}
+
+// Acquires a lock, which is shared only with the locks created in the same directory.
+// Allows locking a specific resource, rather than the JFrog CLI configuration.
+func CreateLockInDir(dir string) (Lock, error) {
+	lockFile := &Lock{dir: dir}
 	err := lockFile.CreateNewLockFile()
 
 	if err != nil {
@@ -248,3 +279,13 @@ func CreateLock() (Lock, error) {
 	}
 	return *lockFile, nil
 }
+
+// Acquires the lock of the configuration, and returns the function which releases it.
+// It is passed to the configuration package as a config.ConfigLocker, for writing back refreshed access tokens.
+func LockConfig() (func() error, error) {
+	lockFile, err := CreateLock()
+	if err != nil {
+		return nil, err
+	}
+	return lockFile.Unlock, nil
+}
@@ -3,8 +3,10 @@ package lock
 import (
 	"fmt"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
 	"math"
 	"os"
+	"sync"
 	"testing"
 	"time"
 )
@@ -166,6 +168,52 @@ func TestCreateFile(t *testing.T) {
 	}
 }
 
+// Locks created by goroutines of the same process should exclude each other.
+func TestCreateLockInDirGoroutines(t *testing.T) {
+	lockDir, err := ioutil.TempDir("", "lock-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(lockDir)
+
+	var wg sync.WaitGroup
+	var holders, maxHolders int
+	var countersMutex sync.Mutex
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lockFile, err := CreateLockInDir(lockDir)
+			defer lockFile.Unlock()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			countersMutex.Lock()
+			holders++
+			if holders > maxHolders {
+				maxHolders = holders
+			}
+			countersMutex.Unlock()
+			time.Sleep(time.Millisecond)
+			countersMutex.Lock()
+			holders--
+			countersMutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	if maxHolders != 1 {
+		t.Error("Expected the lock to be held by a single goroutine at a time, got:", maxHolders)
+	}
+	files, err := fileutils.ListFiles(lockDir, false)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(files) != 0 {
+		t.Error("Expected 0 files but got", len(files), files)
+	}
+}
+
 func getLock(pid int, t *testing.T) (Lock, string) {
 	currentTime := time.Now().UnixNano()
 	lock := Lock{
@@ -2,6 +2,8 @@ package summary
 
 import (
 	"encoding/json"
+	"sync"
+	"time"
 )
 
 type StatusType int
@@ -30,16 +32,75 @@ func New(err error) *Summary {
 	return summary
 }
 
+// Creates a detailed summary of the command, which holds the results of the files it handled.
+// The duration of the command is measured from the creation of the summary.
+func NewDetailed(command string) *Summary {
+	return &Summary{Command: command, Totals: &Totals{}, startTime: time.Now()}
+}
+
 func (summary *Summary) Marshal() ([]byte, error) {
 	return json.Marshal(summary)
 }
 
+// Sets the status, totals and duration of a detailed summary, once the command is done.
+func (summary *Summary) Complete(success, failed int, err error) {
+	summary.Totals.Success = success
+	summary.Totals.Failure = failed
+	summary.Status = Success
+	if err != nil || failed > 0 {
+		summary.Status = Failure
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	summary.DurationMillis = millisSince(summary.startTime)
+}
+
+func (summary *Summary) AddFile(file File) {
+	summary.mutex.Lock()
+	defer summary.mutex.Unlock()
+	summary.Files = append(summary.Files, file)
+}
+
+// Adds the result of a file, which was handled since the start time. The file failed if the error isn't nil.
+func (summary *Summary) AddResult(file File, startTime time.Time, err error) {
+	file.DurationMillis = millisSince(startTime)
+	if err != nil {
+		file.Error = err.Error()
+	}
+	summary.AddFile(file)
+}
+
+func millisSince(startTime time.Time) int64 {
+	return time.Since(startTime).Nanoseconds() / int64(time.Millisecond)
+}
+
 type Summary struct {
-	Status StatusType `json:"status"`
-	Totals *Totals    `json:"totals"`
+	Command        string     `json:"command,omitempty"`
+	Status         StatusType `json:"status"`
+	Totals         *Totals    `json:"totals"`
+	DurationMillis int64      `json:"durationMillis,omitempty"`
+	Files          []File     `json:"files,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	startTime      time.Time
+	mutex          sync.Mutex
 }
 
 type Totals struct {
 	Success int `json:"success"`
 	Failure int `json:"failure"`
 }
+
+// The result of a single file handled by the command.
+type File struct {
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+	// The build-info module of a published artifact.
+	Module         string `json:"module,omitempty"`
+	Sha1           string `json:"sha1,omitempty"`
+	Md5            string `json:"md5,omitempty"`
+	Sha256         string `json:"sha256,omitempty"`
+	Size           int64  `json:"size,omitempty"`
+	DurationMillis int64  `json:"durationMillis,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
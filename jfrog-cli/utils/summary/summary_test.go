@@ -0,0 +1,51 @@
+package summary
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDetailedSummary(t *testing.T) {
+	summary := NewDetailed("delete")
+	summary.AddResult(File{Source: "repo/a.txt", Sha1: "sha1", Size: 3}, time.Now(), nil)
+	summary.AddResult(File{Source: "repo/b.txt"}, time.Now(), errors.New("Artifactory response: 403 Forbidden"))
+	summary.Complete(1, 1, errors.New("Delete finished with errors"))
+
+	if summary.Status != Failure {
+		t.Error("Expected a failure status when files failed.")
+	}
+	if summary.Error != "Delete finished with errors" {
+		t.Error("Unexpected error:", summary.Error)
+	}
+	if len(summary.Files) != 2 || summary.Files[0].Error != "" || summary.Files[1].Error != "Artifactory response: 403 Forbidden" {
+		t.Error("Unexpected files:", summary.Files)
+	}
+
+	content, err := summary.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(content, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result["command"] != "delete" || result["status"] != "failure" {
+		t.Error("Unexpected summary:", string(content))
+	}
+	if files, ok := result["files"].([]interface{}); !ok || len(files) != 2 {
+		t.Error("Unexpected summary files:", string(content))
+	}
+}
+
+func TestSummary(t *testing.T) {
+	content, err := New(nil).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"status":"success","totals":{"success":0,"failure":0}}`
+	if string(content) != expected {
+		t.Error("Expected", expected, "got", string(content))
+	}
+}